@@ -0,0 +1,65 @@
+package day01
+
+import (
+	"context"
+	"slices"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(1, Solver{})
+}
+
+type Solver struct{}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+type Parsed struct {
+	List1, List2 []int
+}
+
+func (Solver) Parse(input string) (Parsed, error) {
+	var list1, list2 []int
+	for _, line := range parse.Input(input).Lines() {
+		ns, err := line.Ints()
+		if err != nil {
+			return Parsed{}, err
+		}
+		if len(ns) != 2 {
+			return Parsed{}, line.Errorf("expected 2 numbers, got %d", len(ns))
+		}
+		list1 = append(list1, ns[0])
+		list2 = append(list2, ns[1])
+	}
+	slices.Sort(list1)
+	slices.Sort(list2)
+	return Parsed{list1, list2}, nil
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	var sum int
+	for i, v := range parsed.List1 {
+		sum += abs(v - parsed.List2[i])
+	}
+	return aoc.Int(sum), nil
+}
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	freqs := make(map[int]int)
+	for _, v := range parsed.List2 {
+		freqs[v]++
+	}
+
+	var sum int
+	for _, v := range parsed.List1 {
+		sum += v * freqs[v]
+	}
+	return aoc.Int(sum), nil
+}
@@ -0,0 +1,11 @@
+package day02
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Bench(b, 2)
+}
@@ -0,0 +1,78 @@
+package day02
+
+import (
+	"context"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(2, Solver{})
+}
+
+type Solver struct{}
+
+func sign(n int) int {
+	if n > 0 {
+		return 1
+	}
+	return -1
+}
+
+type Parsed [][]int
+
+func (Solver) Parse(input string) (Parsed, error) {
+	lines := parse.Input(input).Lines()
+	reports := make(Parsed, len(lines))
+	for i, line := range lines {
+		var err error
+		if reports[i], err = line.Ints(); err != nil {
+			return nil, err
+		}
+	}
+	return reports, nil
+}
+
+func isSafe(ns []int) bool {
+	var dir int
+	for i, v := range ns[1:] {
+		diff := v - ns[i]
+		if dir == 0 {
+			dir = sign(diff)
+		}
+		diff *= dir
+		if diff < 1 || 3 < diff {
+			return false
+		}
+	}
+	return true
+}
+
+func (Solver) Part1(ctx context.Context, reports Parsed) (aoc.Answer, error) {
+	var safe int
+	for _, ns := range reports {
+		if isSafe(ns) {
+			safe++
+		}
+	}
+	return aoc.Int(safe), nil
+}
+
+func (Solver) Part2(ctx context.Context, reports Parsed) (aoc.Answer, error) {
+	var safe int
+	for _, ns := range reports {
+		if isSafe(ns) {
+			safe++
+			continue
+		}
+		for i := 0; i < len(ns); i++ {
+			fixed := append(append([]int{}, ns[:i]...), ns[i+1:]...)
+			if isSafe(fixed) {
+				safe++
+				break
+			}
+		}
+	}
+	return aoc.Int(safe), nil
+}
@@ -0,0 +1,16 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day02
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 2, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "2"},
+		{File: "sample.txt", Part: 2, Want: "4"},
+	})
+}
@@ -0,0 +1,66 @@
+package day03
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(3, Solver{})
+}
+
+type Solver struct{}
+
+type Parsed []string
+
+func (Solver) Parse(input string) (Parsed, error) {
+	var lines Parsed
+	for _, line := range parse.Input(input).Lines() {
+		lines = append(lines, line.S)
+	}
+	return lines, nil
+}
+
+func (Solver) Part1(ctx context.Context, lines Parsed) (aoc.Answer, error) {
+	reMul := regexp.MustCompile(`mul\((\d+),(\d+)\)`)
+	var total int
+	for _, line := range lines {
+		matches := reMul.FindAllStringSubmatch(line, -1)
+		for _, match := range matches {
+			a, _ := strconv.Atoi(match[1])
+			b, _ := strconv.Atoi(match[2])
+			total += a * b
+		}
+	}
+	return aoc.Int(total), nil
+}
+
+func (Solver) Part2(ctx context.Context, lines Parsed) (aoc.Answer, error) {
+	re := regexp.MustCompile(`mul\((\d+),(\d+)\)|do\(\)|don't\(\)`)
+	var total int
+	enabled := true
+	for _, line := range lines {
+		matches := re.FindAllStringSubmatch(line, -1)
+		for _, match := range matches {
+			if match[0] == "do()" {
+				enabled = true
+				continue
+			}
+			if match[0] == "don't()" {
+				enabled = false
+				continue
+			}
+			if !enabled {
+				continue
+			}
+			a, _ := strconv.Atoi(match[1])
+			b, _ := strconv.Atoi(match[2])
+			total += a * b
+		}
+	}
+	return aoc.Int(total), nil
+}
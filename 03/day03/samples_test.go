@@ -0,0 +1,18 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day03
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 3, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "161"},
+		{File: "sample.txt", Part: 2, Want: "161"},
+		{File: "sample2.txt", Part: 1, Want: "161"},
+		{File: "sample2.txt", Part: 2, Want: "48"},
+	})
+}
@@ -0,0 +1,104 @@
+package day04
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(4, Solver{})
+}
+
+type Solver struct{}
+
+type Parsed []string
+
+func (Solver) Parse(input string) (Parsed, error) {
+	in := parse.Input(input)
+	var lines Parsed
+	for _, line := range in.Lines() {
+		if len(lines) > 0 && len(line.S) != len(lines[0]) {
+			return nil, line.Errorf("line length %d, expected %d", len(line.S), len(lines[0]))
+		}
+		lines = append(lines, line.S)
+	}
+	if len(lines) == 0 || len(lines[0]) == 0 {
+		return nil, in.Errorf("empty grid")
+	}
+	return lines, nil
+}
+
+func (Solver) Part1(ctx context.Context, lines Parsed) (aoc.Answer, error) {
+	var xmas int
+
+	xmas += countXMAS(lines)
+	xmas += countXMAS(diagonal(lines))
+
+	for i := 0; i < 3; i++ {
+		lines = rotate90(lines)
+		xmas += countXMAS(lines)
+		xmas += countXMAS(diagonal(lines))
+	}
+	return aoc.Int(xmas), nil
+}
+
+var reXMAS = regexp.MustCompile(`XMAS`)
+
+func countXMAS(lines []string) int {
+	var xmas int
+	for _, line := range lines {
+		xmas += len(reXMAS.FindAllString(line, -1))
+	}
+	return xmas
+}
+
+func rotate90(lines []string) []string {
+	var newLines []string
+	for _, l := range lines {
+		for i, c := range l {
+			if i >= len(newLines) {
+				newLines = append(newLines, "")
+			}
+			newLines[i] = string(c) + newLines[i]
+		}
+	}
+	return newLines
+}
+
+func diagonal(lines []string) []string {
+	var newLines []string
+	for i, l := range lines {
+		for j, c := range l {
+			if i+j >= len(newLines) {
+				newLines = append(newLines, "")
+			}
+			newLines[i+j] += string(c)
+		}
+	}
+	return newLines
+}
+
+func (Solver) Part2(ctx context.Context, lines Parsed) (aoc.Answer, error) {
+	var xmas int
+	if len(lines) < 3 || len(lines[0]) < 3 {
+		return aoc.Int(0), nil // no room for an X
+	}
+	for y, l := range lines[1 : len(lines)-1] {
+		for x, c := range l[1 : len(l)-1] {
+			if c != 'A' {
+				continue
+			}
+			if !(lines[y][x] == 'M' && lines[y+2][x+2] == 'S' || lines[y][x] == 'S' && lines[y+2][x+2] == 'M') {
+				continue
+			}
+			if !(lines[y][x+2] == 'M' && lines[y+2][x] == 'S' || lines[y][x+2] == 'S' && lines[y+2][x] == 'M') {
+				continue
+			}
+			xmas++
+		}
+	}
+	return aoc.Int(xmas), nil
+}
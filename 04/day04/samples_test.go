@@ -0,0 +1,22 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day04
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 4, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "4"},
+		{File: "sample.txt", Part: 2, Want: "0"},
+		{File: "sample2.txt", Part: 1, Want: "18"},
+		{File: "sample2.txt", Part: 2, Want: "9"},
+		{File: "sample3.txt", Part: 1, Want: "18"},
+		{File: "sample3.txt", Part: 2, Want: "3"},
+		{File: "sample4.txt", Part: 1, Want: "0"},
+		{File: "sample4.txt", Part: 2, Want: "0"},
+	})
+}
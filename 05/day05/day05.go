@@ -0,0 +1,109 @@
+package day05
+
+import (
+	"context"
+	"slices"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(5, Solver{})
+}
+
+type Solver struct{}
+
+type Parsed struct {
+	Rules   [][]int
+	Packets [][]int
+}
+
+func (Solver) Parse(input string) (parsed Parsed, err error) {
+	sections, err := parse.Input(input).SectionsN(2)
+	if err != nil {
+		return parsed, err
+	}
+	for _, line := range sections[0].Lines() {
+		rule, err := line.Ints()
+		if err != nil {
+			return parsed, err
+		}
+		if len(rule) != 2 {
+			return parsed, line.Errorf("expected rule like 47|53, got %q", line)
+		}
+		parsed.Rules = append(parsed.Rules, rule)
+	}
+	for _, line := range sections[1].Lines() {
+		packet, err := line.Ints()
+		if err != nil {
+			return parsed, err
+		}
+		parsed.Packets = append(parsed.Packets, packet)
+	}
+	return
+}
+
+func getPositions(packet []int) map[int]int {
+	pos := map[int]int{}
+	for i, n := range packet {
+		pos[n] = i
+	}
+	return pos
+}
+
+func isValid(packet []int, rules [][]int) bool {
+	pos := getPositions(packet)
+	for _, rule := range rules {
+		if i, ok := pos[rule[0]]; ok {
+			if j, ok := pos[rule[1]]; ok {
+				if i > j {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	var sum int
+	for _, packet := range parsed.Packets {
+		if isValid(packet, parsed.Rules) {
+			sum += packet[(len(packet)-1)/2]
+		}
+	}
+	return aoc.Int(sum), nil
+}
+
+func fix(packet []int, rules [][]int) []int {
+	pos := getPositions(packet)
+	for _, rule := range rules {
+		if i, ok := pos[rule[0]]; ok {
+			if j, ok := pos[rule[1]]; ok {
+				if i > j {
+					packet[i], packet[j] = packet[j], packet[i]
+					pos[rule[0]] = j
+					pos[rule[1]] = i
+					return fix(packet, rules)
+				}
+			}
+		}
+	}
+	return packet
+}
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	var sum int
+	for _, packet := range parsed.Packets {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if isValid(packet, parsed.Rules) {
+			continue
+		}
+		fixed := fix(slices.Clone(packet), parsed.Rules)
+		sum += fixed[(len(fixed)-1)/2]
+	}
+	return aoc.Int(sum), nil
+}
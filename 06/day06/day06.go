@@ -0,0 +1,145 @@
+package day06
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(6, Solver{})
+}
+
+type Solver struct{}
+
+var Print = false
+
+type Guard struct {
+	grid.Point
+	dir int // index in grid.Dirs4
+}
+
+type Parsed struct {
+	Grid  grid.Grid[rune]
+	Guard Guard
+}
+
+func (Solver) Parse(input string) (parsed Parsed, err error) {
+	in := parse.Input(input)
+	parsed.Grid, err = in.Grid()
+	if err != nil {
+		return parsed, err
+	}
+	guards := parsed.Grid.FindAll('^')
+	if len(guards) == 0 {
+		return parsed, in.Errorf("no guard found")
+	}
+	if len(guards) > 1 {
+		p := guards[1]
+		return parsed, in.Lines()[p.Y].ErrorAt(p.X, "multiple guards found")
+	}
+	parsed.Guard = Guard{Point: guards[0], dir: 0}
+	return parsed, nil
+}
+
+func walkOut(g grid.Grid[rune], guard Guard) []grid.Point {
+	var path []grid.Point
+	for {
+		if g.At(guard.Point) != 'X' {
+			path = append(path, guard.Point)
+			g.Set(guard.Point, 'X')
+		}
+		np := guard.Add(grid.Dirs4[guard.dir])
+		if !g.In(np) {
+			return path
+		}
+		if g.At(np) == '#' {
+			guard.dir = (guard.dir + 1) % 4 // Turn right
+		} else {
+			guard.Point = np
+		}
+	}
+}
+
+func printGrid(g grid.Grid[rune]) {
+	if Print {
+		fmt.Fprint(aoc.Log, g)
+	}
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	g := parsed.Grid.Clone()
+	walkOut(g, parsed.Guard)
+	count := g.Count('X')
+	printGrid(g)
+	return aoc.Int(count), nil
+}
+
+var Workers = runtime.NumCPU()
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	g := parsed.Grid.Clone()
+	guard := parsed.Guard
+	path := walkOut(g, guard)
+	aoc.Logf("Using %d workers\n", Workers)
+	var loopCount atomic.Int64
+	wg := sync.WaitGroup{}
+	wg.Add(Workers)
+	ch := make(chan grid.Point, Workers)
+	for i := 0; i < Workers; i++ {
+		go func(g grid.Grid[rune]) {
+			defer wg.Done()
+			visited := grid.New[uint8](g.W, g.H) // bit per direction
+			for p := range ch {
+				g.Set(p, '#')
+				if hasLoop(g, guard, visited) {
+					loopCount.Add(1)
+					g.Set(p, 'O')
+				} else {
+					g.Set(p, ' ')
+				}
+			}
+		}(g.Clone())
+	}
+	for _, p := range path {
+		if ctx.Err() != nil {
+			break
+		}
+		if p != guard.Point {
+			ch <- p
+		}
+	}
+	close(ch)
+	wg.Wait()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	printGrid(g)
+	return aoc.Int(int(loopCount.Load())), nil
+}
+
+func hasLoop(g grid.Grid[rune], guard Guard, visited grid.Grid[uint8]) bool {
+	clear(visited.Cells)
+	for {
+		i := visited.Index(guard.Point)
+		if visited.Cells[i]&(1<<guard.dir) != 0 {
+			return true
+		}
+		visited.Cells[i] |= 1 << guard.dir
+		np := guard.Add(grid.Dirs4[guard.dir])
+		if !g.In(np) {
+			return false
+		}
+		if g.At(np) == '#' {
+			guard.dir = (guard.dir + 1) % 4 // Turn right
+		} else {
+			guard.Point = np
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package day07
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(7, Solver{})
+}
+
+type Solver struct{}
+
+func catch(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+type Parsed [][]int
+
+func (Solver) Parse(input string) (Parsed, error) {
+	lines := parse.Input(input).Lines()
+	linesInts := make(Parsed, len(lines))
+	for i, line := range lines {
+		ns, err := line.Ints()
+		if err != nil {
+			return nil, err
+		}
+		if len(ns) < 2 {
+			return nil, line.Errorf("expected result: numbers, got %q", line)
+		}
+		linesInts[i] = ns
+	}
+	return linesInts, nil
+}
+
+func isValid(result int, ns []int) bool {
+	if len(ns) == 1 {
+		return result == ns[0]
+	}
+	if isValid(result-ns[len(ns)-1], ns[:len(ns)-1]) {
+		return true
+	}
+	if result%ns[len(ns)-1] == 0 && isValid(result/ns[len(ns)-1], ns[:len(ns)-1]) {
+		return true
+	}
+	return false
+}
+
+func (Solver) Part1(ctx context.Context, lines Parsed) (aoc.Answer, error) {
+	var sum int
+	for _, line := range lines {
+		if isValid(line[0], line[1:]) {
+			sum += line[0]
+		}
+	}
+	return aoc.Int(sum), nil
+}
+
+func concat(ns []int) int {
+	var s strings.Builder
+	for _, n := range ns {
+		s.WriteString(strconv.Itoa(n))
+	}
+	n, err := strconv.Atoi(s.String())
+	catch(err)
+	return n
+}
+
+// concat|| should also be evaluated left to right !!! No priority
+// so we can't calculate the result of last operation first
+// and have to go left to right instead
+func isValid3(result int, ns []int) bool {
+	if len(ns) == 1 {
+		return result == ns[0]
+	}
+	if isValid3(result, append([]int{ns[0] + ns[1]}, ns[2:]...)) {
+		return true
+	}
+	if isValid3(result, append([]int{ns[0] * ns[1]}, ns[2:]...)) {
+		return true
+	}
+	if isValid3(result, append([]int{concat(ns[:2])}, ns[2:]...)) {
+		return true
+	}
+
+	return false
+}
+
+func (Solver) Part2(ctx context.Context, lines Parsed) (aoc.Answer, error) {
+	var sum int
+	for _, line := range lines {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if isValid3(line[0], line[1:]) {
+			sum += line[0]
+		}
+	}
+	return aoc.Int(sum), nil
+}
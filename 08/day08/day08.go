@@ -0,0 +1,67 @@
+package day08
+
+import (
+	"context"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(8, Solver{})
+}
+
+type Solver struct{}
+
+type Parsed struct {
+	grid.Grid[rune]
+	Nodes map[rune][]grid.Point
+}
+
+func (Solver) Parse(input string) (parsed Parsed, err error) {
+	parsed.Grid, err = parse.Input(input).Grid()
+	if err != nil {
+		return parsed, err
+	}
+	parsed.Nodes = map[rune][]grid.Point{}
+	for p, c := range parsed.All() {
+		if c != '.' {
+			parsed.Nodes[c] = append(parsed.Nodes[c], p)
+		}
+	}
+	return parsed, nil
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	antinodes := grid.New[bool](parsed.W, parsed.H)
+	for _, ns := range parsed.Nodes {
+		for i, pos1 := range ns {
+			for j, pos2 := range ns {
+				if i == j {
+					continue
+				}
+				antinodes.Set(pos1.Mul(2).Sub(pos2), true)
+			}
+		}
+	}
+	return aoc.Int(antinodes.Count(true)), nil
+}
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	antinodes := grid.New[bool](parsed.W, parsed.H)
+	for _, ns := range parsed.Nodes {
+		for i, pos1 := range ns {
+			for j, pos2 := range ns {
+				if i == j {
+					continue
+				}
+				d := pos1.Sub(pos2)
+				for p := pos1; antinodes.In(p); p = p.Add(d) {
+					antinodes.Set(p, true)
+				}
+			}
+		}
+	}
+	return aoc.Int(antinodes.Count(true)), nil
+}
@@ -0,0 +1,128 @@
+package day09
+
+import (
+	"context"
+	"slices"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(9, Solver{})
+}
+
+type Solver struct{}
+
+type Parsed string
+
+func (Solver) Parse(input string) (Parsed, error) {
+	in := parse.Input(input)
+	lines := in.Lines()
+	if len(lines) != 1 {
+		return "", in.Errorf("expected 1 line of disk map, got %d", len(lines))
+	}
+	for i, c := range lines[0].S {
+		if c < '0' || c > '9' {
+			return "", lines[0].ErrorAt(i, "bad digit %q", c)
+		}
+	}
+	return Parsed(lines[0].S), nil
+}
+
+const FREE = -1
+
+func buildDisk(input Parsed) (disk []int) {
+	var id int
+	var free bool
+	for _, r := range input {
+		val := id
+		if free {
+			val = FREE
+		} else {
+			id++
+		}
+		disk = append(disk, slices.Repeat([]int{val}, int(r-'0'))...)
+		free = !free
+	}
+	return
+}
+
+func diskChecksum(disk []int) int {
+	var checksum int
+	for i, v := range disk {
+		if v != FREE {
+			checksum += i * v
+		}
+	}
+	return checksum
+}
+
+func (Solver) Part1(ctx context.Context, input Parsed) (aoc.Answer, error) {
+	disk := buildDisk(input)
+	j := len(disk) - 1
+	for i, v := range disk {
+		if v != FREE {
+			continue
+		}
+		for disk[j] == FREE {
+			j--
+		}
+		if i >= j {
+			break
+		}
+		disk[i] = disk[j]
+		disk[j] = FREE
+		j--
+	}
+	return aoc.Int(diskChecksum(disk)), nil
+}
+
+const NOT_FOUND = -1
+
+func (Solver) Part2(ctx context.Context, input Parsed) (aoc.Answer, error) {
+	disk := buildDisk(input)
+	for j := len(disk) - 1; j >= 0; j-- {
+		if disk[j] == FREE {
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		// get length of file
+		fileLength := 1
+		id := disk[j]
+		for j > 0 && disk[j-1] == id {
+			fileLength++
+			j--
+		}
+
+		// find free space to fit file
+		fitPos := NOT_FOUND
+		for i := 0; i < j; i++ {
+			if disk[i] != FREE {
+				continue
+			}
+			freeLength := 0
+			for i < j && disk[i] == FREE {
+				freeLength++
+				i++
+			}
+			if freeLength >= fileLength {
+				fitPos = i - freeLength
+				break
+			}
+		}
+		if fitPos == NOT_FOUND {
+			continue
+		}
+
+		// move file to fitPos
+		copy(disk[fitPos:], disk[j:j+fileLength])
+		for k := j; k < j+fileLength; k++ {
+			disk[k] = FREE
+		}
+	}
+	return aoc.Int(diskChecksum(disk)), nil
+}
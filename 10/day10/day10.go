@@ -0,0 +1,125 @@
+package day10
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+	"github.com/metalim/adventofcode.2024.go/aoc/vis"
+)
+
+func init() {
+	aoc.Register(10, Solver{})
+}
+
+type Solver struct{}
+
+var PRINT_MAP bool
+
+type Input struct {
+	grid.Grid[rune]
+}
+
+func (Solver) Parse(input string) (Input, error) {
+	g, err := parse.Input(input).Grid()
+	return Input{g}, err
+}
+
+// step is a point on the trail, going down from the peak.
+type step struct {
+	grid.Point
+	peak int
+}
+
+func (Solver) Part1(ctx context.Context, input Input) (aoc.Answer, error) {
+	peaks := input.FindAll('9')
+	next := make([]step, 0, len(peaks))
+	for i, p := range peaks {
+		next = append(next, step{p, i})
+	}
+	var cur []step
+	// steps are grouped by peak, so stamp of the peak at this level is enough to skip duplicates
+	stamps := grid.New[int](input.W, input.H)
+	for v := '8'; v >= '0'; v-- {
+		printMap(input, next, true, "Searching for %c, from %d points", v, len(next))
+		cur, next = next, cur[:0] // reuse, lol
+		for _, s := range cur {
+			stamp := int(v-'0')*len(peaks) + s.peak + 1
+			for np := range input.Neighbors4(s.Point) {
+				if input.At(np) != v || stamps.At(np) == stamp {
+					continue
+				}
+				stamps.Set(np, stamp)
+				next = append(next, step{np, s.peak})
+			}
+		}
+	}
+	printMap(input, next, false, "Final map:")
+
+	// each step left is a trailhead reaching a peak
+	return aoc.Int(len(next)), nil
+}
+
+// Vis is where PRINT_MAP goes.
+var Vis *vis.Recorder
+
+func printMap(input Input, steps []step, printNeighbors bool, format string, a ...interface{}) {
+	if !PRINT_MAP || !Vis.On() {
+		return
+	}
+	points := grid.New[bool](input.W, input.H)
+	for _, s := range steps {
+		points.Set(s.Point, true)
+	}
+	frame := vis.FromGrid(input.Grid, func(p grid.Point, c rune) vis.Cell {
+		if points.At(p) {
+			return vis.Cell{Ch: c, Color: vis.HiYellow}
+		}
+		if printNeighbors {
+			for np := range points.Neighbors4(p) {
+				if points.At(np) {
+					return vis.Cell{Ch: c, Color: vis.Green}
+				}
+			}
+		}
+		if c == '0' {
+			return vis.Cell{Ch: c, Color: vis.Red}
+		}
+		return vis.Cell{Ch: c, Color: vis.HiBlack}
+	})
+	frame.Text = fmt.Sprintf(format, a...)
+	Vis.Add(frame)
+}
+
+func (Solver) Part2(ctx context.Context, input Input) (aoc.Answer, error) {
+	// usage: trails[np]+=trails[p]
+	trails := grid.New[int](input.W, input.H)
+	next := input.FindAll('9')
+	for _, p := range next {
+		trails.Set(p, 1)
+	}
+	var cur []grid.Point
+	for v := '8'; v >= '0'; v-- {
+		cur, next = next, cur[:0]
+		for _, p := range cur {
+			for np := range input.Neighbors4(p) {
+				if input.At(np) != v {
+					continue
+				}
+				if trails.At(np) == 0 {
+					next = append(next, np)
+				}
+				trails.Set(np, trails.At(np)+trails.At(p))
+			}
+		}
+	}
+
+	trailheads := next
+	var sum int
+	for _, p := range trailheads {
+		sum += trails.At(p)
+	}
+	return aoc.Int(sum), nil
+}
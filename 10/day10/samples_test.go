@@ -0,0 +1,18 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day10
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 10, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "1"},
+		{File: "sample.txt", Part: 2, Want: "16"},
+		{File: "sample2.txt", Part: 1, Want: "36"},
+		{File: "sample2.txt", Part: 2, Want: "81"},
+	})
+}
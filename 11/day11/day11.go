@@ -0,0 +1,126 @@
+package day11
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/memo"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(11, Solver{})
+}
+
+type Solver struct{}
+
+const StepsPart1 = 25
+const StepsPart2 = 75
+
+func catch(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+type Input []int
+
+func toInt(s string) int {
+	i, err := strconv.Atoi(s)
+	catch(err)
+	return i
+}
+
+func (Solver) Parse(input string) (Input, error) {
+	nums, err := parse.Input(input).Ints()
+	return Input(nums), err
+}
+
+func blink(ctx context.Context, stones Input, steps int) (Input, error) {
+	next := make(Input, len(stones))
+	for step := 0; step < steps; step++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		for i, stone := range stones {
+			if stone == 0 {
+				next[i] = 1
+				continue
+			}
+			s := strconv.Itoa(stone)
+			if len(s)%2 == 0 {
+				next[i] = toInt(s[:len(s)/2])
+				next = append(next, toInt(s[len(s)/2:]))
+				continue
+			}
+			next[i] = stone * 2024
+		}
+		stones, next = next, stones
+		next = slices.Grow(next, len(stones))[:len(stones)]
+	}
+	return stones, nil
+}
+
+func (Solver) Part1(ctx context.Context, stones Input) (aoc.Answer, error) {
+	stones, err := blink(ctx, slices.Clone(stones), StepsPart1)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(len(stones)), nil
+}
+
+func blinkStoneOnce(stone int) []int {
+	if stone == 0 {
+		return []int{1}
+	}
+	s := strconv.Itoa(stone)
+	if len(s)%2 == 0 {
+		return []int{toInt(s[:len(s)/2]), toInt(s[len(s)/2:])}
+	}
+	return []int{stone * 2024}
+}
+
+// Mem is count of stones after steps, by [stone, steps].
+type Mem = memo.Cache[[2]int, int]
+
+func blinkStoneCount(mem *Mem, stone int, steps int) int {
+	if v, ok := mem.Get([2]int{stone, steps}); ok {
+		return v
+	}
+
+	next := blinkStoneOnce(stone)
+	mem.Set([2]int{stone, 1}, len(next))
+	if steps == 1 {
+		return len(next)
+	}
+
+	var count int
+	for _, v := range next {
+		count += blinkStoneCount(mem, v, steps-1)
+	}
+	mem.Set([2]int{stone, steps}, count)
+	return count
+}
+
+func blinkStonesCount(ctx context.Context, mem *Mem, stones Input, steps int) (int, error) {
+	var count int
+	for _, stone := range stones {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		count += blinkStoneCount(mem, stone, steps)
+	}
+	return count, nil
+}
+
+func (Solver) Part2(ctx context.Context, stones Input) (aoc.Answer, error) {
+	mem := memo.New[[2]int, int](0)
+	count, err := blinkStonesCount(ctx, mem, stones, StepsPart2)
+	if err != nil {
+		return nil, err
+	}
+	aoc.Logf("Mem: %v\n", mem.Stats())
+	return aoc.Int(count), nil
+}
@@ -0,0 +1,112 @@
+package day12
+
+import (
+	"context"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(12, Solver{})
+}
+
+type Solver struct{}
+
+type Input struct {
+	grid.Grid[rune]
+}
+
+func (Solver) Parse(input string) (Input, error) {
+	g, err := parse.Input(input).Grid()
+	return Input{g}, err
+}
+
+type Plot struct {
+	id     int // in Plots.ids
+	symbol rune
+	points []grid.Point
+}
+
+// Plots are regions of the map, with id of the plot for each cell. 0 is outside of the map.
+type Plots struct {
+	ids   grid.Grid[int]
+	plots []*Plot
+}
+
+func (ps Plots) in(plot *Plot, p grid.Point) bool {
+	return ps.ids.At(p) == plot.id
+}
+
+// fill marks all points of the plot, starting from its first point.
+func fill(input Input, ids grid.Grid[int], plot *Plot) {
+	ids.Set(plot.points[0], plot.id)
+	for i := 0; i < len(plot.points); i++ {
+		for np := range input.Neighbors4(plot.points[i]) {
+			if ids.At(np) != 0 || input.At(np) != plot.symbol {
+				continue
+			}
+			ids.Set(np, plot.id)
+			plot.points = append(plot.points, np)
+		}
+	}
+}
+
+func findPlots(input Input) Plots {
+	ps := Plots{ids: grid.New[int](input.W, input.H)}
+	for p, r := range input.All() {
+		if ps.ids.At(p) != 0 {
+			continue
+		}
+		plot := &Plot{id: len(ps.plots) + 1, symbol: r, points: []grid.Point{p}}
+		fill(input, ps.ids, plot)
+		ps.plots = append(ps.plots, plot)
+	}
+	return ps
+}
+
+func (Solver) Part1(ctx context.Context, input Input) (aoc.Answer, error) {
+	var cost int
+	ps := findPlots(input)
+
+	for _, plot := range ps.plots {
+		area := len(plot.points)
+		var perimeter int
+		for _, p := range plot.points {
+			for _, d := range grid.Dirs4 {
+				if !ps.in(plot, p.Add(d)) {
+					perimeter++
+				}
+			}
+		}
+		cost += area * perimeter
+	}
+	return aoc.Int(cost), nil
+}
+
+func (Solver) Part2(ctx context.Context, input Input) (aoc.Answer, error) {
+	ps := findPlots(input)
+
+	var cost int
+	for _, plot := range ps.plots {
+		area := len(plot.points)
+		// line of walls is just one side
+		var sides int
+		for _, p := range plot.points {
+			for i, d := range grid.Dirs4 {
+				if ps.in(plot, p.Add(d)) {
+					continue
+				}
+				sideP := p.Add(grid.Dirs4[(i+1)%4])
+				if ps.in(plot, sideP) && !ps.in(plot, sideP.Add(d)) {
+					continue
+				}
+				sides++
+			}
+		}
+
+		cost += area * sides
+	}
+	return aoc.Int(cost), nil
+}
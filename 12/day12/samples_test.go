@@ -0,0 +1,24 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day12
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 12, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "140"},
+		{File: "sample.txt", Part: 2, Want: "80"},
+		{File: "sample2.txt", Part: 1, Want: "772"},
+		{File: "sample2.txt", Part: 2, Want: "436"},
+		{File: "sample3.txt", Part: 1, Want: "1930"},
+		{File: "sample3.txt", Part: 2, Want: "1206"},
+		{File: "sample4.txt", Part: 1, Want: "692"},
+		{File: "sample4.txt", Part: 2, Want: "236"},
+		{File: "sample5.txt", Part: 1, Want: "1184"},
+		{File: "sample5.txt", Part: 2, Want: "368"},
+	})
+}
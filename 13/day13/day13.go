@@ -0,0 +1,136 @@
+package day13
+
+import (
+	"context"
+	"math"
+	"regexp"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(13, Solver{})
+}
+
+type Solver struct{}
+
+const (
+	Acost = 3
+	Bcost = 1
+
+	Part1MaxPresses = 100
+	Part2Add        = 1e13
+)
+
+type Input []Machine
+
+type Machine struct {
+	A     Point
+	B     Point
+	Prize Point
+}
+
+type Point struct {
+	X int
+	Y int
+}
+
+func (p Point) AddInt(v int) Point {
+	return Point{p.X + v, p.Y + v}
+}
+
+var reMachine = regexp.MustCompile(`^Button A: X\+(?P<ax>\d+), Y\+(?P<ay>\d+)\nButton B: X\+(?P<bx>\d+), Y\+(?P<by>\d+)\nPrize: X=(?P<px>\d+), Y=(?P<py>\d+)$`)
+
+type machineBlock struct {
+	AX int `re:"ax"`
+	AY int `re:"ay"`
+	BX int `re:"bx"`
+	BY int `re:"by"`
+	PX int `re:"px"`
+	PY int `re:"py"`
+}
+
+// Parse reads machines from blocks separated by blank lines. Malformed block is an error, not skipped.
+func (Solver) Parse(input string) (Input, error) {
+	blocks, err := parse.ScanEach[machineBlock](parse.Input(input).Sections(), reMachine)
+	if err != nil {
+		return nil, err
+	}
+	machines := make(Input, 0, len(blocks))
+	for _, m := range blocks {
+		buttonA := Point{m.AX, m.AY}
+		buttonB := Point{m.BX, m.BY}
+		prize := Point{m.PX, m.PY}
+		machines = append(machines, Machine{buttonA, buttonB, prize})
+	}
+	return machines, nil
+}
+
+func (Solver) Part1(ctx context.Context, machines Input) (aoc.Answer, error) {
+	var totalMinCost int
+	for _, m := range machines {
+		minCost := math.MaxInt
+		for a := 0; a < Part1MaxPresses; a++ {
+			for b := 0; b < Part1MaxPresses; b++ {
+				if m.A.X*a+m.B.X*b == m.Prize.X && m.A.Y*a+m.B.Y*b == m.Prize.Y {
+					cost := Acost*a + Bcost*b
+					if cost < minCost {
+						minCost = cost
+					}
+				}
+			}
+		}
+		if minCost == math.MaxInt {
+			continue
+		}
+		totalMinCost += minCost
+	}
+	return aoc.Int(totalMinCost), nil
+}
+
+func solve(p, a, b Point) int {
+	/*
+		solve for i and j
+		i*a.X + j*b.X = p.X
+		i*a.Y + j*b.Y = p.Y
+
+		i = (p.X - j*b.X) / a.X
+		(p.X - j*b.X) * a.Y / a.X + j*b.Y = p.Y
+		p.X*a.Y - j*a.Y*b.X + j*a.X*b.Y = p.Y*a.X
+		j*(a.X*b.Y - a.Y*b.X) = p.Y*a.X - p.X*a.Y
+		j = (p.Y*a.X - p.X*a.Y) / (a.X*b.Y - a.Y*b.X)
+
+		same way for i:
+		i = (p.Y*b.X - p.X*b.Y) / (a.X*b.Y - a.Y*b.X)
+
+		or multiply by -1 numerator and denominator to get same denominator (determinant):
+		i = (p.X*b.Y - p.Y*b.X) / (a.X*b.Y - a.Y*b.X)
+	*/
+
+	det := a.X*b.Y - a.Y*b.X
+	if det == 0 {
+		return 0
+	}
+	detA := p.X*b.Y - p.Y*b.X
+	detB := p.Y*a.X - p.X*a.Y
+	if detA%det != 0 || detB%det != 0 {
+		return 0
+	}
+	i := detA / det
+	j := detB / det
+
+	if i < 0 || j < 0 {
+		return 0
+	}
+	return Acost*i + Bcost*j
+}
+
+func (Solver) Part2(ctx context.Context, machines Input) (aoc.Answer, error) {
+	var totalCost int
+	for _, m := range machines {
+		cost := solve(m.Prize.AddInt(Part2Add), m.A, m.B)
+		totalCost += cost
+	}
+	return aoc.Int(totalCost), nil
+}
@@ -0,0 +1,216 @@
+package day14
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+	"github.com/metalim/adventofcode.2024.go/aoc/vis"
+)
+
+func init() {
+	aoc.Register(14, Solver{})
+}
+
+type Solver struct{}
+
+const Part1Moves = 100
+const Part2Moves = 10000
+
+const InputW, InputH = 101, 103
+
+var W, H = InputW, InputH
+
+// Sample space is used for sample files, unless W, H were changed.
+var Sample = Point{11, 7}
+
+func space(ctx context.Context) Point {
+	if W != InputW || H != InputH || !aoc.IsSample(ctx) {
+		return Point{W, H}
+	}
+	return Sample
+}
+
+type Robot struct {
+	P Point
+	V Point
+}
+
+func (r *Robot) Move(t int, size Point) {
+	r.P.X = mod(r.P.X+r.V.X*t, size.X)
+	r.P.Y = mod(r.P.Y+r.V.Y*t, size.Y)
+}
+
+type Point struct {
+	X int
+	Y int
+}
+
+func (p Point) Add(v Point) Point {
+	return Point{X: p.X + v.X, Y: p.Y + v.Y}
+}
+
+type Input []*Robot
+
+var reRobot = regexp.MustCompile(`^p=(?P<px>-?\d+),(?P<py>-?\d+) v=(?P<vx>-?\d+),(?P<vy>-?\d+)$`)
+
+type robotLine struct {
+	PX int `re:"px"`
+	PY int `re:"py"`
+	VX int `re:"vx"`
+	VY int `re:"vy"`
+}
+
+func (Solver) Parse(input string) (Input, error) {
+	lines, err := parse.ScanLines[robotLine](parse.Input(input), reRobot)
+	if err != nil {
+		return nil, err
+	}
+	robots := make(Input, 0, len(lines))
+	for _, l := range lines {
+		r := &Robot{
+			P: Point{X: l.PX, Y: l.PY},
+			V: Point{X: l.VX, Y: l.VY},
+		}
+		robots = append(robots, r)
+	}
+	return robots, nil
+}
+
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
+
+func safetyFactor(robots Input, size Point) int {
+	W, H := size.X, size.Y
+	var safety [4]int
+	for _, r := range robots {
+		switch {
+		case r.P.X < W/2 && r.P.Y < H/2:
+			safety[0]++
+		case r.P.X > W/2 && r.P.Y < H/2:
+			safety[1]++
+		case r.P.X < W/2 && r.P.Y > H/2:
+			safety[2]++
+		case r.P.X > W/2 && r.P.Y > H/2:
+			safety[3]++
+		default:
+			// ignore robots that are not in any quadrant
+		}
+	}
+	return safety[0] * safety[1] * safety[2] * safety[3]
+}
+
+func (robots Input) Clone() Input {
+	clone := make(Input, len(robots))
+	for i, r := range robots {
+		clone[i] = &Robot{P: r.P, V: r.V}
+	}
+	return clone
+}
+
+func (Solver) Part1(ctx context.Context, robots Input) (aoc.Answer, error) {
+	size := space(ctx)
+	robots = robots.Clone()
+	aoc.Logf("Moving %d robots %d times in a %dx%d grid\n", len(robots), Part1Moves, size.X, size.Y)
+	for _, r := range robots {
+		r.Move(Part1Moves, size)
+	}
+	return aoc.Int(safetyFactor(robots, size)), nil
+}
+
+func bit(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+var quarters = []rune(" ▘▝▀▖▌▞▛▗▚▐▜▄▙▟█")
+
+// Print records robots at each step closer to the tree.
+var Print = false
+
+// Vis is where Print goes.
+var Vis *vis.Recorder
+
+// gridCompact draws 2x2 robot cells as one quarter-block rune.
+func gridCompact(robots Input, size Point) vis.Frame {
+	W, H := size.X, size.Y
+	taken := map[Point]bool{}
+	for _, r := range robots {
+		taken[r.P] = true
+	}
+	frame := vis.NewFrame((W+1)/2, (H+1)/2)
+	for y := 0; y < H; y += 2 {
+		for x := 0; x < W; x += 2 {
+			var bits int
+			var nBits int
+			for dy := 0; dy < 2; dy++ {
+				for dx := 0; dx < 2; dx++ {
+					p := Point{x + dx, y + dy}
+					if taken[p] {
+						bits |= 1 << (dy*2 + dx)
+						nBits++
+					}
+				}
+			}
+			c := vis.Cell{Ch: quarters[bits], Color: vis.White}
+			switch nBits {
+			case 2:
+				c.Color = vis.Red
+			case 3, 4:
+				c.Color = vis.Green
+			}
+			frame.Set(grid.Point{X: x / 2, Y: y / 2}, c)
+		}
+	}
+	return frame
+}
+
+func (Solver) Part2(ctx context.Context, robots Input) (aoc.Answer, error) {
+	size := space(ctx)
+	movingRobots := robots.Clone()
+	minMetric := math.MaxInt
+	var minStep int
+	for i := 1; i <= Part2Moves; i++ {
+		if i%1000 == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var avg Point
+		for _, r := range movingRobots {
+			r.Move(1, size)
+			avg = avg.Add(r.P)
+		}
+		avg = Point{X: avg.X / len(movingRobots), Y: avg.Y / len(movingRobots)}
+		var asd int // average squared deviation
+		for _, r := range movingRobots {
+			asd += (r.P.X-avg.X)*(r.P.X-avg.X) + (r.P.Y-avg.Y)*(r.P.Y-avg.Y)
+		}
+		asd = asd / len(movingRobots)
+		if asd < minMetric {
+			minMetric = asd
+			minStep = i
+			aoc.Logf("New min metric: %d at step %d\n", minMetric, minStep)
+			if Print && Vis.On() {
+				frame := gridCompact(movingRobots, size)
+				frame.Text = fmt.Sprintf("step %d, deviation %d", minStep, minMetric)
+				Vis.Add(frame)
+			}
+		}
+		if minMetric == 0 {
+			break
+		}
+	}
+
+	robots = robots.Clone()
+	for _, r := range robots {
+		r.Move(minStep, size)
+	}
+	gridCompact(robots, size).Fprint(aoc.Log)
+	return aoc.Int(minStep), nil
+}
@@ -0,0 +1,15 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day14
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 14, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "12"},
+	})
+}
@@ -0,0 +1,163 @@
+package day15
+
+import (
+	"context"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(15, Solver{})
+}
+
+type Solver struct{}
+
+const GPSY = 100
+const Space = ' '
+
+var Print = false
+var Print1 = false
+var Print2 = false
+
+type Input struct {
+	Room         grid.Grid[rune]
+	Instructions string
+}
+
+func (Solver) Parse(input string) (Input, error) {
+	sections, err := parse.Input(input).SectionsN(2) // room and instructions
+	if err != nil {
+		return Input{}, err
+	}
+	room, err := sections[0].Grid()
+	return Input{
+		Room:         room,
+		Instructions: strings.ReplaceAll(sections[1].S, "\n", ""),
+	}, err
+}
+
+type Point = grid.Point
+
+var directions = map[rune]Point{
+	'v': grid.Down,
+	'^': grid.Up,
+	'>': grid.Right,
+	'<': grid.Left,
+}
+
+func canMove(p Point, dir Point, room grid.Grid[rune]) bool {
+	np := p.Add(dir)
+	switch room.At(np) {
+	case '#':
+		return false
+	case 'O':
+		return canMove(np, dir, room)
+	case '[':
+		if dir.X == 0 {
+			return canMove(np, dir, room) && canMove(np.Add(Point{X: 1, Y: 0}), dir, room)
+		}
+		return canMove(np, dir, room)
+	case ']':
+		if dir.X == 0 {
+			return canMove(np, dir, room) && canMove(np.Add(Point{X: -1, Y: 0}), dir, room)
+		}
+		return canMove(np, dir, room)
+	default:
+		return true
+	}
+}
+
+func move(p Point, dir Point, room grid.Grid[rune]) (Point, bool) {
+	np := p.Add(dir)
+	switch room.At(np) {
+	case '#':
+		return p, false
+	case 'O':
+		_, ok := move(np, dir, room)
+		if !ok {
+			return p, false
+		}
+	case '[':
+		if dir.X == 0 {
+			move(np.Add(Point{X: 1, Y: 0}), dir, room)
+		}
+		move(np, dir, room)
+	case ']':
+		if dir.X == 0 {
+			move(np.Add(Point{X: -1, Y: 0}), dir, room)
+		}
+		move(np, dir, room)
+	}
+	room.Set(np, room.At(p))
+	room.Set(p, Space)
+	return np, true
+}
+
+func (Solver) Part1(ctx context.Context, input Input) (aoc.Answer, error) {
+	if Print1 {
+		saved := Print
+		Print = true
+		defer func() {
+			Print = saved
+		}()
+	}
+	room := input.Room.Clone()
+	robot, _ := room.Find('@')
+	printGrid(room, 0, input.Instructions)
+	for i, instruction := range input.Instructions {
+		robot, _ = move(robot, directions[instruction], room)
+		printGrid(room, i, input.Instructions)
+	}
+	var sum int
+	for pos, c := range room.All() {
+		if c == 'O' {
+			sum += pos.X + pos.Y*GPSY
+		}
+	}
+	return aoc.Int(sum), nil
+}
+
+func (Solver) Part2(ctx context.Context, input Input) (aoc.Answer, error) {
+	if Print2 {
+		saved := Print
+		Print = true
+		defer func() {
+			Print = saved
+		}()
+	}
+	room := grid.New[rune](input.Room.W*2, input.Room.H)
+	var robot Point
+	for p, c := range input.Room.All() {
+		left, right := Point{X: p.X * 2, Y: p.Y}, Point{X: p.X*2 + 1, Y: p.Y}
+		switch c {
+		case 'O':
+			room.Set(left, '[')
+			room.Set(right, ']')
+		case '@':
+			room.Set(left, c)
+			room.Set(right, Space)
+			robot = left
+		default:
+			room.Set(left, c)
+			room.Set(right, c)
+		}
+	}
+	printGrid(room, 0, input.Instructions)
+	for i, instruction := range input.Instructions {
+		if canMove(robot, directions[instruction], room) {
+			robot, _ = move(robot, directions[instruction], room)
+		}
+
+		printGrid(room, i, input.Instructions)
+	}
+	var sum int
+	for pos, c := range room.All() {
+		if c == '[' {
+			sum += pos.X + pos.Y*GPSY
+		}
+	}
+	return aoc.Int(sum), nil
+}
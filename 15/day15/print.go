@@ -0,0 +1,34 @@
+package day15
+
+import (
+	"fmt"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+	"github.com/metalim/adventofcode.2024.go/aoc/vis"
+)
+
+// Vis records the room after each move, if Print is on.
+var Vis *vis.Recorder
+
+var colors = map[rune]vis.Color{
+	'#': vis.Red,
+	'@': vis.HiGreen,
+	'O': vis.Yellow,
+	'[': vis.Yellow,
+	']': vis.Yellow,
+}
+
+func printGrid(room grid.Grid[rune], i int, instructions string) {
+	if !Print || !Vis.On() {
+		return
+	}
+	frame := vis.FromGrid(room, func(_ grid.Point, c rune) vis.Cell {
+		return vis.Cell{Ch: c, Color: colors[c]}
+	})
+	next := '*'
+	if i < len(instructions)-1 {
+		next = rune(instructions[i+1])
+	}
+	frame.Text = fmt.Sprintf("%d/%d, instruction: %c, next: %c", i+1, len(instructions), instructions[i], next)
+	Vis.Add(frame)
+}
@@ -0,0 +1,20 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day15
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 15, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "10092"},
+		{File: "sample.txt", Part: 2, Want: "9021"},
+		{File: "sample2.txt", Part: 1, Want: "2028"},
+		{File: "sample2.txt", Part: 2, Want: "1751"},
+		{File: "sample3.txt", Part: 1, Want: "908"},
+		{File: "sample3.txt", Part: 2, Want: "618"},
+	})
+}
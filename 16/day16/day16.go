@@ -0,0 +1,145 @@
+package day16
+
+import (
+	"context"
+	"iter"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+	"github.com/metalim/adventofcode.2024.go/aoc/search"
+)
+
+func init() {
+	aoc.Register(16, Solver{})
+}
+
+type Solver struct{}
+
+type Dir int
+
+const (
+	Right Dir = iota
+	Up
+	Left
+	Down
+)
+
+var Directions = []grid.Point{
+	Right: grid.Right,
+	Up:    grid.Up,
+	Left:  grid.Left,
+	Down:  grid.Down,
+}
+
+type Parsed struct {
+	Map grid.Grid[rune]
+
+	Start, End grid.Point
+}
+
+func (Solver) Parse(input string) (parsed Parsed, err error) {
+	in := parse.Input(input)
+	parsed.Map, err = in.Grid()
+	if err != nil {
+		return parsed, err
+	}
+	var ok bool
+	if parsed.Start, ok = parsed.Map.Find('S'); !ok {
+		return parsed, in.Errorf("no start")
+	}
+	if parsed.End, ok = parsed.Map.Find('E'); !ok {
+		return parsed, in.Errorf("no end")
+	}
+	return parsed, nil
+}
+
+type Deer struct {
+	Pos grid.Point
+	Dir Dir
+}
+
+const Wall = '#'
+
+// graph of deer states, where step costs 1, and turning costs 1000 more.
+func graph(m grid.Grid[rune]) search.Graph[Deer] {
+	return search.Graph[Deer]{
+		Neighbors: func(deer Deer) iter.Seq2[Deer, int] {
+			return func(yield func(Deer, int) bool) {
+				for dir, dirVec := range Directions {
+					dir := Dir(dir)
+					nDeer := Deer{Pos: deer.Pos.Add(dirVec), Dir: dir}
+					if !m.In(nDeer.Pos) || m.At(nDeer.Pos) == Wall {
+						continue
+					}
+					cost := 1
+					if dir != deer.Dir {
+						cost += 1000
+					}
+					if !yield(nDeer, cost) {
+						return
+					}
+				}
+			}
+		},
+		Index: func(deer Deer) int {
+			return m.Index(deer.Pos)*len(Directions) + int(deer.Dir)
+		},
+		Size: len(m.Cells) * len(Directions),
+	}
+}
+
+func (parsed Parsed) isEnd(deer Deer) bool {
+	return deer.Pos == parsed.End
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	start := Deer{Pos: parsed.Start, Dir: 0}
+	paths := search.Dijkstra(graph(parsed.Map), []Deer{start}, parsed.isEnd)
+	_, minScore, err := paths.Goal()
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(minScore), nil
+}
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	m := parsed.Map
+	start := Deer{Pos: parsed.Start, Dir: 0}
+	paths := search.AllPaths(graph(m), []Deer{start}, parsed.isEnd)
+	_, minScore, err := paths.Goal()
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	// deer can reach the end facing any direction with the same min score
+	var ends []Deer
+	for dir := range Directions {
+		end := Deer{Pos: parsed.End, Dir: Dir(dir)}
+		if score, ok := paths.Dist(end); ok && score == minScore {
+			ends = append(ends, end)
+		}
+	}
+	tiles := grid.New[bool](m.W, m.H)
+	for _, deer := range paths.DAG(ends...) {
+		tiles.Set(deer.Pos, true)
+	}
+	return aoc.Int(tiles.Count(true)), nil
+}
+
+/*
+########
+#.....E#
+#.####.#
+#S.....#
+########
+
+#     #     #     #     #     #     #     #
+#  1002^ 2003> 2004> 2005> 2006> 1007^    #
+#  1001^    #     #     #     #  1006^    #
+#     0>    1>    2>    3>    4>    5>    #
+#     #     #     #     #     #     #     #
+
+*/
@@ -0,0 +1,18 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day16
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 16, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "7036"},
+		{File: "sample.txt", Part: 2, Want: "45"},
+		{File: "sample2.txt", Part: 1, Want: "11048"},
+		{File: "sample2.txt", Part: 2, Want: "64"},
+	})
+}
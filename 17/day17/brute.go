@@ -1,9 +1,11 @@
-package main
+package day17
 
 import (
-	"fmt"
+	"context"
 	"runtime"
 	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
 )
 
 func run2(program []int, a int) bool {
@@ -60,40 +62,58 @@ func run2(program []int, a int) bool {
 
 var Workers = runtime.NumCPU()
 
-func part2_brute(parsed Parsed) {
-	timeStart := time.Now()
+func part2_brute(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
 	if From == 0 {
-		fmt.Println(`!!! This will take a "few" days !!!`)
-		fmt.Println(`You might want the --from <val>`)
+		aoc.Logf("!!! This will take a \"few\" days !!!\n")
+		aoc.Logf("You might want the --from <val>\n")
 	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stop the other workers
 	printCh := make(chan int)
-	outCh := make(chan int)
+	outCh := make(chan int, Workers)
 	for i := 0; i < Workers; i++ {
-		go worker(parsed, From+i, Workers, outCh, printCh)
+		go worker(ctx, parsed, From+i, Workers, outCh, printCh)
+	}
+	go printWorker(ctx, printCh)
+	select {
+	case a := <-outCh:
+		aoc.Logf("a: %b\n", a)
+		return aoc.Int(a), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-	go printWorker(printCh)
-	a := <-outCh
-	fmt.Printf("Part 2: %d %b\t\tin %v\n", a, a, time.Since(timeStart))
 }
 
-func printWorker(printCh chan int) {
+func printWorker(ctx context.Context, printCh chan int) {
 	t := time.Now()
 	var aPrev int
-	for a := range printCh {
-		fmt.Printf("a: %d, %.0f/s\n", a, float64(a-aPrev)/(time.Since(t).Seconds()))
-		t = time.Now()
-		aPrev = a
+	for {
+		select {
+		case a := <-printCh:
+			aoc.Logf("a: %d, %.0f/s\n", a, float64(a-aPrev)/(time.Since(t).Seconds()))
+			t = time.Now()
+			aPrev = a
+		case <-ctx.Done():
+			return
+		}
 	}
 }
 
-func worker(parsed Parsed, from, step int, outCh chan int, printCh chan int) {
-	for a := from; ; a += step {
+func worker(ctx context.Context, parsed Parsed, from, step int, outCh chan int, printCh chan int) {
+	for a, i := from, 0; ; a, i = a+step, i+1 {
+		if i%1e6 == 0 && ctx.Err() != nil {
+			return
+		}
 		if a%1e9 == 0 {
-			printCh <- a
+			select {
+			case printCh <- a:
+			case <-ctx.Done():
+				return
+			}
 		}
 		if run2(parsed.program, a) {
 			outCh <- a
-			break
+			return
 		}
 	}
 }
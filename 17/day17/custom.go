@@ -1,13 +1,12 @@
-package main
+package day17
 
 import (
-	"fmt"
 	"slices"
-	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
 )
 
-func part2_custom(parsed Parsed) {
-	start := time.Now()
+func part2_custom(parsed Parsed) (aoc.Answer, error) {
 	outs := [][]int{
 		{2, 4, 1, 1, 7, 5, 0, 3, 1, 4, 4, 5, 5, 5, 3, 0},
 		{2, 4, 1, 2, 7, 5, 1, 7, 4, 4, 0, 3, 5, 5, 3, 0},
@@ -26,18 +25,17 @@ func part2_custom(parsed Parsed) {
 	for i, o := range outs {
 		if slices.Compare(parsed.program, o) == 0 {
 			fn = fns[i]
-			fmt.Println("found formula for", o)
+			aoc.Logf("found formula for %v\n", o)
 		}
 	}
 	if fn == nil {
-		fmt.Println("Unsupported input, sorry")
-		return
+		return nil, aoc.ErrUnsupported
 	}
 
-	if a, ok := findA(parsed.program, 0, fn); ok {
-		confirmed := run2(parsed.program, a)
-		fmt.Printf("Part 2 custom: %d, confirmed: %t\t\tin %v\n", a, confirmed, time.Since(start))
-	} else {
-		fmt.Println("Part 2 custom: solution not found")
+	a, ok := findA(parsed.program, 0, fn)
+	if !ok {
+		return nil, ErrNotFound
 	}
+	aoc.Logf("confirmed: %t\n", run2(parsed.program, a))
+	return aoc.Int(a), nil
 }
@@ -0,0 +1,183 @@
+package day17
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(17, Solver{})
+}
+
+type Solver struct{}
+
+var (
+	Custom bool
+	Print  bool
+	Brute  bool
+	From   int
+)
+
+type Parsed struct {
+	program   []int
+	registers [3]int
+}
+
+var reRegister = regexp.MustCompile(`^Register (?P<name>[ABC]): (?P<value>\d+)$`)
+var reProgram = regexp.MustCompile(`^Program: (?P<program>[\d,]+)$`)
+
+type registerLine struct {
+	Value int `re:"value"`
+}
+
+type programLine struct {
+	Program []int `re:"program"`
+}
+
+func (Solver) Parse(input string) (Parsed, error) {
+	parts, err := parse.Input(input).SectionsN(2)
+	if err != nil {
+		return Parsed{}, err
+	}
+	registers, err := parse.ScanLines[registerLine](parts[0], reRegister)
+	if err != nil {
+		return Parsed{}, err
+	}
+	if len(registers) > 3 {
+		return Parsed{}, parts[0].Errorf("expected up to 3 registers, got %d", len(registers))
+	}
+	program, err := parse.Scan[programLine](parts[1], reProgram)
+	if err != nil {
+		return Parsed{}, err
+	}
+	parsed := Parsed{program: program.Program}
+	for i, register := range registers {
+		parsed.registers[i] = register.Value
+	}
+	return parsed, nil
+}
+
+func pow(base, exp int) int {
+	result := 1
+	for i := 0; i < exp; i++ {
+		result *= base
+	}
+	return result
+}
+
+func run(program []int, reg [3]int) ([3]int, []int) {
+	var output []int
+	for i := 0; i < len(program); i += 2 {
+		opcode := program[i]
+		literal := program[i+1]
+
+		var combo int
+		switch literal {
+		case 0, 1, 2, 3:
+			combo = literal
+		case 4:
+			combo = reg[0]
+		case 5:
+			combo = reg[1]
+		case 6:
+			combo = reg[2]
+		case 7:
+			// ignore
+		}
+
+		switch opcode {
+		case 0: // adv
+			reg[0] >>= combo
+		case 1: // bxl
+			reg[1] ^= literal
+		case 2: // bst
+			reg[1] = combo % 8
+		case 3: // jnz
+			if reg[0] != 0 {
+				i = literal - 2
+			}
+		case 4: // bxc
+			reg[1] ^= reg[2]
+		case 5: // out
+			output = append(output, combo%8)
+		case 6: // bdv
+			reg[1] = reg[0] >> combo
+		case 7: // cdv
+			reg[2] = reg[0] >> combo
+		}
+	}
+	return reg, output
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	_, output := run(parsed.program, parsed.registers)
+	var s strings.Builder
+
+	for i, o := range output {
+		if i > 0 {
+			s.WriteString(",")
+		}
+		s.WriteString(strconv.Itoa(o))
+	}
+	return aoc.String(s.String()), nil
+}
+
+var jnz0 = []int{3, 0}
+
+// ErrNotFound is returned when no value of register A makes the program output itself.
+var ErrNotFound = errors.New("solution not found")
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	if Custom {
+		return part2_custom(parsed)
+	}
+	if Brute {
+		return part2_brute(ctx, parsed)
+	}
+	i := len(parsed.program) - len(jnz0)
+	if i < 0 || slices.Compare(parsed.program[i:], jnz0) != 0 {
+		return nil, aoc.ErrUnsupported
+	}
+	cycle := parsed.program[:i]
+	fn := func(a int) int {
+		_, out := run(cycle, [3]int{a, 0, 0})
+		return out[0]
+	}
+	a, ok := findA(parsed.program, 0, fn)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	aoc.Logf("confirmed: %t\n", run2(parsed.program, a))
+	return aoc.Int(a), nil
+}
+
+type Fn func(a int) int
+
+func findA(out []int, a int, fn Fn) (int, bool) {
+	if len(out) == 0 {
+		return a, true
+	}
+	i := len(out) - 1
+	o := out[i]
+	a <<= 3
+	for bits := 0; bits < 8; bits++ {
+		na := a | bits
+		v := fn(na)
+		if v == o {
+			if Print {
+				aoc.Logf("i: %d, o: %d, a: %b\n", i, o, na)
+			}
+			if found, ok := findA(out[:i], na, fn); ok {
+				return found, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,17 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day17
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 17, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "4,6,3,5,6,3,5,2,1,0"},
+		{File: "sample2.txt", Part: 1, Want: "5,7,3,0"},
+		{File: "sample2.txt", Part: 2, Want: "117440"},
+	})
+}
@@ -0,0 +1,226 @@
+package day18
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+	"github.com/metalim/adventofcode.2024.go/aoc/search"
+	"github.com/metalim/adventofcode.2024.go/aoc/vis"
+)
+
+func init() {
+	aoc.Register(18, Solver{})
+}
+
+type Solver struct{}
+
+const (
+	LengthInput  = 1024
+	LengthSample = 12
+)
+
+var LengthPart1 = LengthInput
+var PrintGrid = false
+var BinarySearch = false
+
+type Point = grid.Point
+
+type Parsed struct {
+	Points []Point
+	BR     Point
+}
+
+func (Solver) Parse(input string) (Parsed, error) {
+	lines := parse.Input(input).Lines()
+	parsed := Parsed{Points: make([]Point, len(lines))}
+	for i, line := range lines {
+		xy, err := line.Ints()
+		if err != nil {
+			return parsed, err
+		}
+		if len(xy) != 2 || xy[0] < 0 || xy[1] < 0 {
+			return parsed, line.Errorf("expected X,Y, got %q", line)
+		}
+		parsed.Points[i] = Point{X: xy[0], Y: xy[1]}
+		if parsed.BR.X < parsed.Points[i].X {
+			parsed.BR.X = parsed.Points[i].X
+		}
+		if parsed.BR.Y < parsed.Points[i].Y {
+			parsed.BR.Y = parsed.Points[i].Y
+		}
+	}
+	return parsed, nil
+}
+
+// Graph of free cells.
+func (g Grid) Graph() search.Graph[Point] {
+	return search.Graph[Point]{
+		Neighbors: func(p Point) iter.Seq2[Point, int] {
+			return func(yield func(Point, int) bool) {
+				for np := range g.Neighbors4(p) {
+					if !g.At(np) && !yield(np, 1) {
+						return
+					}
+				}
+			}
+		},
+		Index: g.Index,
+		Size:  len(g.Cells),
+	}
+}
+
+func isAt(end Point) func(Point) bool {
+	return func(p Point) bool { return p == end }
+}
+
+// Grid of corrupted bytes.
+type Grid struct {
+	grid.Grid[bool]
+}
+
+// BR is the bottom right corner.
+func (g Grid) BR() Point {
+	return Point{X: g.W - 1, Y: g.H - 1}
+}
+
+// Vis is where PrintGrid goes.
+var Vis *vis.Recorder
+
+// Print records the grid, with ps highlighted.
+func (g Grid) Print(text string, ps ...Point) {
+	if !PrintGrid || !Vis.On() {
+		return
+	}
+	frame := vis.FromGrid(g.Grid, func(p Point, corrupted bool) vis.Cell {
+		if slices.Contains(ps, p) {
+			return vis.Cell{Ch: '#', Color: vis.HiRed}
+		}
+		if corrupted {
+			return vis.Cell{Ch: '#'}
+		}
+		return vis.Cell{Ch: '.'}
+	})
+	frame.Text = text
+	Vis.Add(frame)
+}
+
+func NewGrid(parsed Parsed, length int) Grid {
+	g := Grid{grid.New[bool](parsed.BR.X+1, parsed.BR.Y+1)}
+	for i, p := range parsed.Points {
+		if i == length {
+			break
+		}
+		g.Set(p, true)
+	}
+	return g
+}
+
+var Start = Point{X: 0, Y: 0}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	length := LengthPart1
+	if length == LengthInput && aoc.IsSample(ctx) {
+		length = LengthSample // example from the task
+	}
+	g := NewGrid(parsed, length)
+	g.Print(fmt.Sprintf("%d bytes fallen", length))
+	end := g.BR()
+	paths := search.AStar(g.Graph(), []Point{Start}, isAt(end), end.Manhattan)
+	_, steps, err := paths.Goal()
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(steps), nil
+}
+
+// ErrNotFound is returned when the exit never gets cut off.
+var ErrNotFound = errors.New("no solution found")
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	if BinarySearch {
+		return part2_binary_search(parsed)
+	}
+	return part2_cut(parsed)
+}
+
+func answer(step int, p Point) aoc.Answer {
+	aoc.Logf("step %d\n", step)
+	return aoc.String(fmt.Sprintf("%d,%d", p.X, p.Y))
+}
+
+func part2_binary_search(parsed Parsed) (aoc.Answer, error) {
+	step := sort.Search(len(parsed.Points), func(i int) bool {
+		g := NewGrid(parsed, i+1)
+		_, _, err := search.BFS(g.Graph(), []Point{Start}, isAt(g.BR())).Goal()
+		return err != nil
+	})
+	if step == len(parsed.Points) {
+		return nil, ErrNotFound
+	}
+	g := NewGrid(parsed, step)
+	g.Print(fmt.Sprintf("byte %d cuts the exit off", step+1), parsed.Points[step])
+	return answer(step, parsed.Points[step]), nil
+}
+
+type CutSet struct {
+	Points []Point
+	TR, BL bool
+}
+
+func findJoin(parsed Parsed) (int, Point) {
+	sets := grid.New[*CutSet](parsed.BR.X+1, parsed.BR.Y+1)
+	for steps, p := range parsed.Points {
+		var pSet *CutSet
+		for np := range sets.Neighbors8(p) {
+			if npSet := sets.At(np); npSet != nil {
+				if pSet == nil {
+					pSet = npSet
+				} else if pSet == npSet {
+					continue
+				} else {
+					pSet.TR = pSet.TR || npSet.TR
+					pSet.BL = pSet.BL || npSet.BL
+					if pSet.TR && pSet.BL {
+						return steps, p
+					}
+					// merge sets
+					for _, o := range npSet.Points {
+						sets.Set(o, pSet)
+					}
+					pSet.Points = append(pSet.Points, npSet.Points...)
+				}
+			}
+		}
+		if pSet == nil {
+			pSet = &CutSet{}
+		}
+		pSet.Points = append(pSet.Points, p)
+		if p.X == 0 || p.Y == parsed.BR.Y {
+			pSet.BL = true
+		}
+		if p.X == parsed.BR.X || p.Y == 0 {
+			pSet.TR = true
+		}
+		if pSet.BL && pSet.TR {
+			return steps, p
+		}
+		sets.Set(p, pSet)
+	}
+	return -1, Point{X: -1, Y: -1}
+}
+
+func part2_cut(parsed Parsed) (aoc.Answer, error) {
+	steps, p := findJoin(parsed)
+	if steps == -1 {
+		return nil, ErrNotFound
+	}
+	NewGrid(parsed, steps+1).Print(fmt.Sprintf("byte %d cuts the exit off", steps+1), p)
+	return answer(steps, p), nil
+}
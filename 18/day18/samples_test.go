@@ -0,0 +1,16 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day18
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 18, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "22"},
+		{File: "sample.txt", Part: 2, Want: "6,1"},
+	})
+}
@@ -0,0 +1,90 @@
+package day19
+
+import (
+	"context"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/memo"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(19, Solver{})
+}
+
+type Solver struct{}
+
+type Parsed struct {
+	Patterns []string
+	Designs  []string
+}
+
+func (Solver) Parse(input string) (Parsed, error) {
+	parts, err := parse.Input(input).SectionsN(2)
+	if err != nil {
+		return Parsed{}, err
+	}
+	patterns := strings.Split(parts[0].S, ", ")
+	var designs []string
+	for _, line := range parts[1].Lines() {
+		designs = append(designs, line.S)
+	}
+	return Parsed{patterns, designs}, nil
+}
+
+// Towels count ways to make designs from patterns, remembering counts for this set of patterns only.
+type Towels struct {
+	patterns []string
+	mem      *memo.Cache[string, int]
+}
+
+func NewTowels(patterns []string) *Towels {
+	return &Towels{patterns: patterns, mem: memo.New[string, int](0)}
+}
+
+func (t *Towels) getPossible(design string) int {
+	if v, ok := t.mem.Get(design); ok {
+		return v
+	}
+	var count int
+	for _, pattern := range t.patterns {
+		nd := strings.TrimPrefix(design, pattern)
+		if len(nd) == len(design) {
+			continue
+		}
+		if nd == "" {
+			count++
+			continue
+		}
+		count += t.getPossible(nd)
+	}
+	t.mem.Set(design, count)
+	return count
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	towels := NewTowels(parsed.Patterns)
+	var count int
+	for _, design := range parsed.Designs {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if towels.getPossible(design) > 0 {
+			count++
+		}
+	}
+	return aoc.Int(count), nil
+}
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	towels := NewTowels(parsed.Patterns)
+	var count int
+	for _, design := range parsed.Designs {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		count += towels.getPossible(design)
+	}
+	return aoc.Int(count), nil
+}
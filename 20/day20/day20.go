@@ -0,0 +1,172 @@
+package day20
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+	"github.com/metalim/adventofcode.2024.go/aoc/search"
+)
+
+func init() {
+	aoc.Register(20, Solver{})
+}
+
+type Solver struct{}
+
+const (
+	CheatTime1 = 2
+	CheatTime2 = 20
+)
+
+var (
+	SaveAtLeast1 int = 100
+	SaveAtLeast2 int = 100
+)
+
+type Point = grid.Point
+
+type Parsed struct {
+	Map   grid.Grid[rune]
+	Start Point
+	End   Point
+}
+
+func (Solver) Parse(input string) (*Parsed, error) {
+	in := parse.Input(input)
+	m, err := in.Grid()
+	if err != nil {
+		return nil, err
+	}
+	parsed := &Parsed{Map: m}
+	var ok bool
+	if parsed.Start, ok = m.Find('S'); !ok {
+		return nil, in.Errorf("no start")
+	}
+	if parsed.End, ok = m.Find('E'); !ok {
+		return nil, in.Errorf("no end")
+	}
+	return parsed, nil
+}
+
+const Wall = '#'
+
+func (parsed *Parsed) graph() search.Graph[Point] {
+	m := parsed.Map
+	return search.Graph[Point]{
+		Neighbors: func(p Point) iter.Seq2[Point, int] {
+			return func(yield func(Point, int) bool) {
+				for nb := range m.Neighbors4(p) {
+					if m.At(nb) != Wall && !yield(nb, 1) {
+						return
+					}
+				}
+			}
+		},
+		Index: m.Index,
+		Size:  len(m.Cells),
+	}
+}
+
+// bfs saves steps to reach points, until the end
+func bfs(parsed *Parsed, start, end Point) (steps int, visited *search.Paths[Point], err error) {
+	visited = search.BFS(parsed.graph(), []Point{start}, func(p Point) bool { return p == end })
+	_, steps, err = visited.Goal()
+	return steps, visited, err
+}
+
+// brain! work!
+// there were DFS tasks last days, so brain is **buzzled** with mem + DFS
+// (typo is nice)
+// Do we really need DFS? It just keeps popping in my head
+// Nice move, Eric! 2 relaxing days and alignment with the task
+// I think same trick was used in previous years, not sure
+//
+// 50 minutes in, and still no vision
+// Cursor keeps suggesting BULLSHIT, that also is distracting
+// Fuck you, Cursor! You hear me?
+//
+// Ok, vision is following:
+// 1. BFS forward from the start
+// 2. BFS backward from the end
+// 3. cheat and count
+// easy!
+
+func findWaysToCheat(ctx context.Context, parsed *Parsed, start, end Point, saveAtLeast, maxCheatTime int) (ways, stepsWithoutCheating int, err error) {
+	// 1. simple bfs first, fill forward map
+	stepsForward, forward, err := bfs(parsed, start, end)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// 2. bfs backwards
+	stepsBackward, backward, err := bfs(parsed, end, start)
+	if err != nil {
+		return 0, 0, err
+	}
+	if stepsForward != stepsBackward {
+		return 0, 0, fmt.Errorf("wut? %d steps forward, %d steps backward", stepsForward, stepsBackward)
+	}
+
+	// 3. iterate over all visited points and check if we can still cheat from there
+	// 2 cheat steps = 1 wall, because we need to land on empty space again
+	for _, p := range forward.Reached() {
+		if ctx.Err() != nil {
+			return 0, 0, ctx.Err()
+		}
+		// p = (1,3)
+		cheatStep, _ := forward.Dist(p)
+		if cheatStep > stepsForward-saveAtLeast-2 {
+			continue
+		}
+		// now we need to skip up to maxCheatTime
+		for dy := -maxCheatTime; dy <= maxCheatTime; dy++ {
+			cheatTimeLeft := maxCheatTime - abs(dy)
+			for dx := -cheatTimeLeft; dx <= cheatTimeLeft; dx++ {
+				np := Point{X: p.X + dx, Y: p.Y + dy}
+				if !parsed.Map.In(np) {
+					continue
+				}
+				if parsed.Map.At(np) == Wall {
+					continue
+				}
+				cheatTime := abs(dx) + abs(dy)
+				if backStep, ok := backward.Dist(np); ok {
+					if cheatStep+cheatTime+backStep <= stepsForward-saveAtLeast {
+						ways++
+					}
+				}
+			}
+		}
+	}
+
+	return ways, stepsForward, nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func (Solver) Part1(ctx context.Context, parsed *Parsed) (aoc.Answer, error) {
+	ways, stepsWithoutCheating, err := findWaysToCheat(ctx, parsed, parsed.Start, parsed.End, SaveAtLeast1, CheatTime1)
+	if err != nil {
+		return nil, err
+	}
+	aoc.Logf("%d ways to save %d steps out of %d, with cheat time %d\n", ways, SaveAtLeast1, stepsWithoutCheating, CheatTime1)
+	return aoc.Int(ways), nil
+}
+
+func (Solver) Part2(ctx context.Context, parsed *Parsed) (aoc.Answer, error) {
+	ways, stepsWithoutCheating, err := findWaysToCheat(ctx, parsed, parsed.Start, parsed.End, SaveAtLeast2, CheatTime2)
+	if err != nil {
+		return nil, err
+	}
+	aoc.Logf("%d ways to save %d steps out of %d, with cheat time %d\n", ways, SaveAtLeast2, stepsWithoutCheating, CheatTime2)
+	return aoc.Int(ways), nil
+}
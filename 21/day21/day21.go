@@ -0,0 +1,291 @@
+package day21
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/memo"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(21, Solver{})
+}
+
+type Solver struct{}
+
+var Verbose bool
+
+type Parsed []string
+
+func (Solver) Parse(input string) (Parsed, error) {
+	var codes Parsed
+	for _, line := range parse.Input(input).Lines() {
+		if !strings.HasSuffix(line.S, "A") {
+			return nil, line.Errorf("expected code like 029A, got %q", line)
+		}
+		if _, err := line.Slice(0, len(line.S)-1).Int(); err != nil {
+			return nil, err
+		}
+		codes = append(codes, line.S)
+	}
+	return codes, nil
+}
+
+type Point struct {
+	X int
+	Y int
+}
+
+type Keypad map[rune]Point
+
+func (k Keypad) String() string {
+	var runes []rune
+	for c := range k {
+		runes = append(runes, c)
+	}
+	sort.Slice(runes, func(i, j int) bool {
+		return runes[i] < runes[j]
+	})
+	return string(runes)
+}
+
+/*
++---+---+---+
+| 7 | 8 | 9 | 0
++---+---+---+
+| 4 | 5 | 6 | 1
++---+---+---+
+| 1 | 2 | 3 | 2
++---+---+---+
+. X | 0 | A | 3
+.   +---+---+
+*/
+var numKeypad = Keypad{
+	'7': {0, 0},
+	'8': {1, 0},
+	'9': {2, 0},
+	'4': {0, 1},
+	'5': {1, 1},
+	'6': {2, 1},
+	'1': {0, 2},
+	'2': {1, 2},
+	'3': {2, 2},
+	'X': {0, 3},
+	'0': {1, 3},
+	'A': {2, 3},
+}
+
+/*
+.   +---+---+
+. X | ^ | A | 0
++---+---+---+
+| < | v | > | 1
++---+---+---+
+*/
+var dirKeypad = Keypad{
+	'X': {0, 0},
+	'^': {1, 0},
+	'A': {2, 0},
+	'<': {0, 1},
+	'v': {1, 1},
+	'>': {2, 1},
+}
+
+/*
+sample input:
+029A
+980A
+179A
+456A
+379A
+sample answer should be: 126384
+
+029A: v<A<AA>>^AvAA^<A>Av<<A>>^AvA^Av<A>^A<Av<A>>^AAvA^Av<A<A>>^AAAvA^<A>A
+980A: v<<A>>^AAAvA^Av<A<AA>>^AvAA^<A>Av<A<A>>^AAAvA^<A>Av<A>^A<A>A
+179A: v<<A>>^Av<A<A>>^AAvAA^<A>Av<<A>>^AAvA^Av<A>^AA<A>Av<A<A>>^AAAvA^<A>A
+456A: v<<A>>^AAv<A<A>>^AAvAA^<A>Av<A>^A<A>Av<A>^A<A>Av<A<A>>^AAvA^<A>A
+379A: v<<A>>^AvA^Av<<A>>^AAv<A<A>>^AAvAA^<A>Av<A>^AA<A>Av<A<A>>^AAAvA^<A>A    <-- longer
+
+029A: <vA<AA>>^AvAA<^A>A<v<A>>^AvA^A<vA>^A<v<A>^A>AAvA^A<v<A>A>^AAAvA<^A>A
+980A: <v<A>>^AAAvA^A<vA<AA>>^AvAA<^A>A<v<A>A>^AAAvA<^A>A<vA>^A<A>A
+179A: <v<A>>^A<vA<A>>^AAvAA<^A>A<v<A>>^AAvA^A<vA>^AA<A>A<v<A>A>^AAAvA<^A>A
+456A: <v<A>>^AA<vA<A>>^AAvAA<^A>A<vA>^A<A>A<vA>^A<A>A<v<A>A>^AAvA<^A>A
+379A: <v<A>>^AvA^A<vA<AA>>^AAvA<^A>AAvA^A<vA>^AA<A>A<v<A>A>^AAAvA<^A>A
+
+too long
+379A: v<<A >>^A vA ^A v<<A >>^AA v<A <A >>^AA vAA ^<A >A v<A >^AA <A >A v<A <A >>^AAA vA ^<A >A
+.        <    A  >  A    <    AA   v  <    AA  >>   ^  A   v   AA  ^  A   v  <    AAA  >   ^  A
+.             ^     A         ^^           <<          A       >>     A           vvv         A
+.                   3                                  7              9                       A
+379A: <v<A >>^A vA ^A <vA <AA >>^AA vA <^A >AA vA ^A <vA >^AA <A >A <v<A >A >^AAA vA <^A >A
+.        <    A  >  A   v  <<    AA  >   ^  AA  >  A   v   AA  ^  A    <  v   AAA  >   ^  A
+.             ^     A            <<         ^^     A       >>     A           vvv         A
+.                   3                              7              9                       A
+
+do we need DFS for that?
+*/
+
+type Mover struct {
+	strings.Builder
+}
+
+func (m *Mover) horizontal(dx int) *Mover {
+	for dx < 0 {
+		m.WriteRune('<')
+		dx++
+	}
+	for dx > 0 {
+		m.WriteRune('>')
+		dx--
+	}
+	return m
+}
+
+func (m *Mover) vertical(dy int) *Mover {
+	for dy < 0 {
+		m.WriteRune('^')
+		dy++
+	}
+	for dy > 0 {
+		m.WriteRune('v')
+		dy--
+	}
+	return m
+}
+
+func (m *Mover) pressA() *Mover {
+	m.WriteRune('A')
+	return m
+}
+
+var Moves map[Point][]string
+
+func init() {
+	Moves = make(map[Point][]string)
+	for dy := -3; dy <= 3; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			moves := []string{(&Mover{}).vertical(dy).horizontal(dx).pressA().String()}
+			if dx != 0 && dy != 0 {
+				moves = append(moves, (&Mover{}).horizontal(dx).vertical(dy).pressA().String())
+			}
+			Moves[Point{dx, dy}] = moves
+		}
+	}
+}
+
+func movesOverEmpty(p Point, move string, keypad Keypad) bool {
+	for _, c := range move {
+		switch c {
+		case 'v':
+			p.Y++
+		case '^':
+			p.Y--
+		case '<':
+			p.X--
+		case '>':
+			p.X++
+		}
+		if keypad['X'] == p {
+			return true
+		}
+	}
+	return false
+}
+
+type MemoKey struct {
+	input   string
+	keypads int
+}
+type MemoValue struct {
+	length int
+	ok     bool
+}
+
+type Mem = memo.Cache[MemoKey, MemoValue]
+
+func dfs(mem *Mem, input string, keypads []Keypad) (int, bool) {
+	key := MemoKey{input: input, keypads: len(keypads)}
+	if cached, ok := mem.Get(key); ok {
+		return cached.length, cached.ok
+	}
+	p := keypads[0]['A']
+	var length int
+	for _, c := range input {
+		np := keypads[0][c]
+		dx := np.X - p.X
+		dy := np.Y - p.Y
+		// we have 2 options:
+		// 1. vertical -> horizontal
+		// 2. horizontal -> vertical
+		// also we need to avoid empty space
+		var shortest int
+		var found bool
+		for _, move := range Moves[Point{dx, dy}] {
+			if movesOverEmpty(p, move, keypads[0]) {
+				continue
+			}
+			if len(keypads) == 1 {
+				shortest = len(move)
+				found = true
+				break
+			}
+			if candidate, ok := dfs(mem, move, keypads[1:]); ok {
+				if !found || candidate < shortest {
+					shortest = candidate
+					found = true
+				}
+			}
+		}
+		if !found {
+			mem.Set(key, MemoValue{length: 0, ok: false})
+			return 0, false
+		}
+		length += shortest
+		p = np
+	}
+	mem.Set(key, MemoValue{length: length, ok: true})
+	return length, true
+}
+
+func getSum(parsed Parsed, n int) (int, error) {
+	mem := memo.New[MemoKey, MemoValue](0) // per call, because number of keypads is changing, and so does their meaning
+	keypads := []Keypad{numKeypad}
+	for i := 0; i < n; i++ {
+		keypads = append(keypads, dirKeypad)
+	}
+	var sum int
+	for _, line := range parsed {
+		ops, ok := dfs(mem, line, keypads)
+		if !ok {
+			return 0, fmt.Errorf("no moves found for %s", line)
+		}
+		num, err := strconv.Atoi(line[:len(line)-1])
+		if err != nil {
+			return 0, err
+		}
+		if Verbose {
+			aoc.Logf("%d * %d = %d\n", num, ops, num*ops)
+		}
+		sum += num * ops
+	}
+	return sum, nil
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	sum, err := getSum(parsed, 2)
+	return aoc.Int(sum), err
+}
+
+// sigh...
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	sum, err := getSum(parsed, 25)
+	return aoc.Int(sum), err
+}
+
+// too many tasks can be done via DFS+memoization, which is stupid
+// even BFS tasks have more variety of solutions
@@ -0,0 +1,95 @@
+package day22
+
+import (
+	"context"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(22, Solver{})
+}
+
+type Solver struct{}
+
+type Parsed []int
+
+func (Solver) Parse(input string) (Parsed, error) {
+	lines := parse.Input(input).Lines()
+	ints := make([]int, len(lines))
+	for i, line := range lines {
+		var err error
+		if ints[i], err = line.Int(); err != nil {
+			return nil, err
+		}
+	}
+	return ints, nil
+}
+
+const Mod = 16777216
+const Repeat = 2000
+
+func hash(n int) int {
+	n = (n ^ (n * 64)) % Mod
+	n = (n ^ (n / 32)) % Mod
+	n = (n ^ (n * 2048)) % Mod
+	return n
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	var sum int
+	for _, n := range parsed {
+		for i := 0; i < Repeat; i++ {
+			n = hash(n)
+		}
+		sum += n
+	}
+	return aoc.Int(sum), nil
+}
+
+type Seq [4]int
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	seqsProfit := make(map[Seq]int)
+	saw := make(map[Seq]bool)
+	for _, n := range parsed {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var seq [4]int
+		clear(saw)
+
+		for i := 0; i < Repeat; i++ {
+			prevPrice := n % 10
+			n = hash(n)
+			price := n % 10
+			delta := price - prevPrice
+			seq[0], seq[1], seq[2], seq[3] = seq[1], seq[2], seq[3], delta
+
+			// 0, 1, 2, 3
+			// at 3 we have the sequence of 4 price changes
+			if i < 3 {
+				// we have no sequence yet
+				continue
+			}
+			// we need only the first seq for this n
+			if !saw[seq] {
+				seqsProfit[seq] += price
+				saw[seq] = true
+			}
+		}
+	}
+	aoc.Logf("Seqs: %d\n", len(seqsProfit))
+
+	var maxBananas int
+	var maxSeq Seq
+	for seq, price := range seqsProfit {
+		if price > maxBananas {
+			maxBananas = price
+			maxSeq = seq
+		}
+	}
+	aoc.Logf("Best sequence: %v\n", maxSeq)
+	return aoc.Int(maxBananas), nil
+}
@@ -0,0 +1,18 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day22
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 22, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "37327623"},
+		{File: "sample.txt", Part: 2, Want: "24"},
+		{File: "sample2.txt", Part: 1, Want: "37990510"},
+		{File: "sample2.txt", Part: 2, Want: "23"},
+	})
+}
@@ -0,0 +1,124 @@
+package day23
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(23, Solver{})
+}
+
+type Solver struct{}
+
+type Parsed map[string]map[string]bool
+
+func (Solver) Parse(input string) (Parsed, error) {
+	parsed := make(Parsed)
+	for _, line := range parse.Input(input).Lines() {
+		a, b, err := line.KeyValue("-")
+		if err != nil {
+			return nil, err
+		}
+		if b.S == "" {
+			return nil, b.Errorf("expected computer after -")
+		}
+		parts := []string{a.S, b.S}
+		if parsed[parts[0]] == nil {
+			parsed[parts[0]] = make(map[string]bool)
+		}
+		if parsed[parts[1]] == nil {
+			parsed[parts[1]] = make(map[string]bool)
+		}
+		parsed[parts[0]][parts[1]] = true
+		parsed[parts[1]][parts[0]] = true
+	}
+	return parsed, nil
+}
+
+type Triplet [3]string
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	// find triplets, where each computes is connected to the other two
+
+	triplets := make(map[Triplet]bool)
+	for c1, cs := range parsed {
+		for c2 := range cs {
+			for c3 := range parsed[c2] {
+				if c3 == c1 {
+					continue
+				}
+				if !parsed[c3][c1] {
+					continue
+				}
+				t := Triplet{c1, c2, c3}
+
+				if c1[0] == 't' || c2[0] == 't' || c3[0] == 't' {
+					sort.Strings(t[:])
+					triplets[t] = true
+				}
+			}
+		}
+	}
+	return aoc.Int(len(triplets)), nil
+}
+
+type Party map[string]bool
+
+func dfs(connected Parsed, c string, visited Party) Party {
+	visited[c] = true
+
+	var largestParty Party
+NextC2:
+	for c2 := range connected[c] {
+		if visited[c2] {
+			continue
+		}
+		// check c2 is connected to all visited
+		for prev := range visited {
+			// Problem: if prev c is not deleted from visited, it will be in this loop
+			// and we will check connections to unnecessary node. Yet it gives correct answer.
+			// (╯°□°)╯︵ ┻━┻
+			// I'll commit this for now, but I'll be back.
+			if !connected[c2][prev] {
+				continue NextC2
+			}
+		}
+		// it is connected to all visited, so we can add it to the party
+		party := dfs(connected, c2, visited)
+		if len(party) > len(largestParty) {
+			largestParty = party
+		}
+	}
+	// no more connected, so the current one is the largest party
+	if len(largestParty) == 0 {
+		largestParty = maps.Clone(visited)
+	}
+	// visited[c] = false // why does this work????? it leaves c in the map, just with false value
+	// delete(visited, c) // and this goes into loops... wtf?
+	// special input fuckery? (╯°□°)╯︵ ┻━┻
+	return largestParty
+}
+
+// does it need memo? :)
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	var largestParty Party
+	for c1 := range parsed {
+		party := dfs(parsed, c1, make(Party))
+		if len(party) > len(largestParty) {
+			largestParty = party
+		}
+	}
+	sorted := make([]string, 0, len(largestParty))
+	for c := range largestParty {
+		sorted = append(sorted, c)
+	}
+	sort.Strings(sorted)
+	aoc.Logf("Largest party: %d\n", len(largestParty))
+	return aoc.String(strings.Join(sorted, ",")), nil
+}
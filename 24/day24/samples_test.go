@@ -0,0 +1,18 @@
+// Code generated by go run ./cmd/samples -w; DO NOT EDIT.
+
+package day24
+
+import (
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+func TestSamples(t *testing.T) {
+	aoctest.Samples(t, 24, []aoctest.Sample{
+		{File: "sample.txt", Part: 1, Want: "4"},
+		{File: "sample2.txt", Part: 1, Want: "2024"},
+		{File: "sample3.txt", Part: 1, Want: "9"},
+		{File: "sample3.txt", Part: 2, Want: "z00,z01,z02,z05", Skip: "solver fails: input is not supported: 6 z wires for 6 bit inputs, not an adder"},
+	})
+}
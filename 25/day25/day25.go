@@ -0,0 +1,89 @@
+package day25
+
+import (
+	"context"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+func init() {
+	aoc.Register(25, Solver{})
+}
+
+type Solver struct{}
+
+type Parsed [][]string
+
+func (Solver) Parse(input string) (Parsed, error) {
+	// don't parse numbers, that'll be the task itself lol
+	// (I did parse in previous years, and found out parsing is the main part of the task)
+	var parsed [][]string
+	for _, section := range parse.Input(input).Sections() {
+		var grid []string
+		for _, line := range section.Lines() {
+			if len(line.S) != 5 {
+				return nil, line.Errorf("expected 5 columns, got %q", line)
+			}
+			grid = append(grid, line.S)
+		}
+		if len(grid) != 7 {
+			return nil, section.Errorf("expected 7 rows, got %d", len(grid))
+		}
+		parsed = append(parsed, grid)
+	}
+	return parsed, nil
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	var keys, locks [][5]int
+	for _, grid := range parsed {
+		if grid[0] == "#####" {
+			var lock [5]int
+			for x := 0; x < 5; x++ {
+				for y := 1; y <= 5; y++ {
+					if grid[y][x] == '#' {
+						lock[x] = y
+					}
+				}
+			}
+			locks = append(locks, lock)
+		} else {
+			var key [5]int
+			for x := 0; x < 5; x++ {
+				for y := 5; y >= 1; y-- {
+					if grid[y][x] == '#' {
+						key[x] = 6 - y
+					}
+				}
+			}
+			keys = append(keys, key)
+		}
+	}
+
+	var fitCount int
+	for _, key := range keys {
+		for _, lock := range locks {
+			fit := true
+			for x := 0; x < 5; x++ {
+				if key[x]+lock[x] > 5 {
+					fit = false
+					break
+				}
+			}
+			if fit {
+				fitCount++
+			}
+		}
+	}
+	return aoc.Int(fitCount), nil
+}
+
+// Day 25 has no part 2, the last star is given for all other stars.
+func (Solver) Parts() int {
+	return 1
+}
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	return nil, aoc.ErrNoPart
+}
@@ -0,0 +1,41 @@
+package day99
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+)
+
+func init() {
+	aoc.Register(99, Solver{})
+}
+
+type Solver struct{}
+
+type Parsed []string
+
+func (Solver) Parse(input string) (Parsed, error) {
+	lines := strings.Split(input, "\n")
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return Parsed(lines), nil
+}
+
+func (Solver) Part1(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	for _, line := range parsed {
+		fmt.Println(line)
+	}
+
+	return aoc.Int(0), nil
+}
+
+func (Solver) Part2(ctx context.Context, parsed Parsed) (aoc.Answer, error) {
+	for _, line := range parsed {
+		_ = line
+	}
+
+	return aoc.Int(0), nil
+}
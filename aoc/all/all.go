@@ -0,0 +1,30 @@
+// Package all registers solvers of all days, for tools that run any of them.
+package all
+
+import (
+	_ "github.com/metalim/adventofcode.2024.go/01/day01"
+	_ "github.com/metalim/adventofcode.2024.go/02/day02"
+	_ "github.com/metalim/adventofcode.2024.go/03/day03"
+	_ "github.com/metalim/adventofcode.2024.go/04/day04"
+	_ "github.com/metalim/adventofcode.2024.go/05/day05"
+	_ "github.com/metalim/adventofcode.2024.go/06/day06"
+	_ "github.com/metalim/adventofcode.2024.go/07/day07"
+	_ "github.com/metalim/adventofcode.2024.go/08/day08"
+	_ "github.com/metalim/adventofcode.2024.go/09/day09"
+	_ "github.com/metalim/adventofcode.2024.go/10/day10"
+	_ "github.com/metalim/adventofcode.2024.go/11/day11"
+	_ "github.com/metalim/adventofcode.2024.go/12/day12"
+	_ "github.com/metalim/adventofcode.2024.go/13/day13"
+	_ "github.com/metalim/adventofcode.2024.go/14/day14"
+	_ "github.com/metalim/adventofcode.2024.go/15/day15"
+	_ "github.com/metalim/adventofcode.2024.go/16/day16"
+	_ "github.com/metalim/adventofcode.2024.go/17/day17"
+	_ "github.com/metalim/adventofcode.2024.go/18/day18"
+	_ "github.com/metalim/adventofcode.2024.go/19/day19"
+	_ "github.com/metalim/adventofcode.2024.go/20/day20"
+	_ "github.com/metalim/adventofcode.2024.go/21/day21"
+	_ "github.com/metalim/adventofcode.2024.go/22/day22"
+	_ "github.com/metalim/adventofcode.2024.go/23/day23"
+	_ "github.com/metalim/adventofcode.2024.go/24/day24"
+	_ "github.com/metalim/adventofcode.2024.go/25/day25"
+)
@@ -0,0 +1,91 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// AnswersFile is the file in the day folder with known correct answers.
+const AnswersFile = "answers.json"
+
+// Answers maps input file name and part to the correct answer.
+type Answers map[string]map[int]string
+
+// LoadAnswers reads answers of the day. A day without the file has no answers yet.
+func LoadAnswers(root string, day int) (Answers, error) {
+	answers := Answers{}
+	bs, err := os.ReadFile(filepath.Join(root, Dir(day), AnswersFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return answers, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &answers); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// Save writes answers of the day.
+func (a Answers) Save(root string, day int) error {
+	bs, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	bs = append(bs, '\n')
+	return os.WriteFile(filepath.Join(root, Dir(day), AnswersFile), bs, 0644)
+}
+
+// Get returns the answer for the part of the input.
+func (a Answers) Get(input string, part int) (string, bool) {
+	answer, ok := a[input][part]
+	return answer, ok
+}
+
+// Set records the answer for the part of the input.
+func (a Answers) Set(input string, part int, answer string) {
+	if a[input] == nil {
+		a[input] = map[int]string{}
+	}
+	a[input][part] = answer
+}
+
+var reInput = regexp.MustCompile(`^(input|sample).*\.txt$`)
+
+// Inputs lists input files of the day, relative to the day folder:
+// input*.txt and sample*.txt, including custom ones in subfolders.
+func Inputs(root string, day int) ([]string, error) {
+	dir := filepath.Join(root, Dir(day))
+	var inputs []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "o1" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		name := d.Name()
+		// skip disassembled programs of day 17, like input_assembly.txt
+		if !reInput.MatchString(name) || strings.HasSuffix(name, "_assembly.txt") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, filepath.ToSlash(rel))
+		return nil
+	})
+	slices.Sort(inputs)
+	return inputs, err
+}
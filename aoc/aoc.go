@@ -0,0 +1,70 @@
+// Package aoc holds what all days have in common: the Solver interface,
+// typed answers and the registry every day registers into.
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Answer is the result of one part. Most days answer with a number, some with a string.
+type Answer interface {
+	fmt.Stringer
+}
+
+// Int is a numeric answer.
+type Int int
+
+func (i Int) String() string {
+	return strconv.Itoa(int(i))
+}
+
+// String is a textual answer, like "1,2,3" or "ab,cd,ef".
+type String string
+
+func (s String) String() string {
+	return string(s)
+}
+
+var (
+	// ErrUnsupported is returned by a part that can't solve the given input.
+	ErrUnsupported = errors.New("input is not supported")
+	// ErrNoPart is returned for a part that doesn't exist, like part 2 of day 25.
+	ErrNoPart = errors.New("no such part")
+	// ErrTimeout is returned for a part that ran out of time.
+	ErrTimeout = errors.New("timeout")
+)
+
+type inputKey struct{}
+
+// WithInput tells parts the name of the input file. Days with sizes that are not in the input,
+// like the 11x7 space of the sample of day 14, choose them by it.
+func WithInput(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, inputKey{}, name)
+}
+
+// InputName is the name of the input file the part runs on, or "" if it's not from a file.
+func InputName(ctx context.Context) string {
+	name, _ := ctx.Value(inputKey{}).(string)
+	return name
+}
+
+// IsSample reports if the part runs on a sample file, like sample.txt or sample2.txt.
+func IsSample(ctx context.Context) bool {
+	return strings.HasPrefix(filepath.Base(InputName(ctx)), "sample")
+}
+
+// Log receives everything solvers print besides answers: progress, debug info, grids.
+// Tools that only need answers set it to io.Discard.
+var Log io.Writer = os.Stdout
+
+// Logf prints to Log.
+func Logf(format string, a ...any) {
+	fmt.Fprintf(Log, format, a...)
+}
@@ -0,0 +1,93 @@
+// Package aoctest runs registered solvers in tests against samples with known answers, and in benchmarks.
+package aoctest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+// Sample is an input file in the day folder, with expected answer for one part.
+type Sample struct {
+	File string
+	Part int
+	Want string
+	Skip string // reason to skip, if solver is known to disagree
+}
+
+// Samples checks answers of the day on samples. Tests run in NN/dayNN, so files are read from the parent folder.
+func Samples(t *testing.T, day int, samples []Sample) {
+	t.Helper()
+	puzzle := get(t, day)
+	for _, s := range samples {
+		t.Run(fmt.Sprintf("%s/part%d", s.File, s.Part), func(t *testing.T) {
+			if s.Skip != "" {
+				t.Skip(s.Skip)
+			}
+			input, err := os.ReadFile(filepath.Join("..", s.File))
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := puzzle.Parse(string(input))
+			if err != nil {
+				t.Fatal(parse.WithFile(err, s.File))
+			}
+			got, err := puzzle.Solve(aoc.WithInput(context.Background(), s.File), parsed, s.Part)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != s.Want {
+				t.Errorf("got %v, want %v", got, s.Want)
+			}
+		})
+	}
+}
+
+// Bench benchmarks each part of the day on input.txt, as sub-benchmarks part1 and part2.
+func Bench(b *testing.B, day int) {
+	puzzle := get(b, day)
+	input, err := os.ReadFile(filepath.Join("..", "input.txt"))
+	if err != nil {
+		b.Skip(err)
+	}
+	parsed, err := puzzle.Parse(string(input))
+	if err != nil {
+		b.Fatal(err)
+	}
+	ctx := aoc.WithInput(context.Background(), "input.txt")
+	for _, part := range puzzle.Parts() {
+		b.Run(fmt.Sprintf("part%d", part), BenchPart(ctx, puzzle, parsed, part))
+	}
+}
+
+// BenchPart benchmarks one part on already parsed input. Parsing is not measured,
+// same as the time printed by each day. What solvers log is discarded. Context tells solvers the input name,
+// see aoc.WithInput.
+func BenchPart(ctx context.Context, puzzle aoc.Puzzle, parsed any, part int) func(b *testing.B) {
+	return func(b *testing.B) {
+		log := aoc.Log
+		aoc.Log = io.Discard
+		defer func() { aoc.Log = log }()
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := puzzle.Solve(ctx, parsed, part); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func get(tb testing.TB, day int) aoc.Puzzle {
+	tb.Helper()
+	puzzle, ok := aoc.Get(day)
+	if !ok {
+		tb.Fatalf("day %d is not registered", day)
+	}
+	return puzzle
+}
@@ -0,0 +1,246 @@
+// Package grid is a dense 2D grid, for days with maps.
+//
+// Cells are kept in one slice, row by row, so lookups and "visited" marks
+// don't allocate, unlike map[Point]T. Access outside of the grid is safe:
+// At returns zero value, Set does nothing.
+package grid
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+type Point struct {
+	X, Y int
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+func (p Point) Mul(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Manhattan distance between points.
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("%d,%d", p.X, p.Y)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Directions, with Y going down.
+var (
+	Up    = Point{0, -1}
+	Right = Point{1, 0}
+	Down  = Point{0, 1}
+	Left  = Point{-1, 0}
+
+	// Dirs4 go clockwise from Up, so turning right is (i+1)%4, and turning left is (i+3)%4.
+	Dirs4 = []Point{Up, Right, Down, Left}
+	// Dirs8 go clockwise from Up, with diagonals in between.
+	Dirs8 = []Point{Up, {1, -1}, Right, {1, 1}, Down, {-1, 1}, Left, {-1, -1}}
+)
+
+// Grid is W×H cells of T.
+type Grid[T comparable] struct {
+	W, H  int
+	Cells []T // row by row
+}
+
+// New makes a grid filled with zero values.
+func New[T comparable](w, h int) Grid[T] {
+	return Grid[T]{W: w, H: h, Cells: make([]T, w*h)}
+}
+
+// Parse makes a rune grid from lines of the input. Trailing empty line is ignored.
+func Parse(input string) (Grid[rune], error) {
+	lines := strings.Split(strings.TrimRight(input, "\n"), "\n")
+	return FromLines(lines)
+}
+
+// FromLines makes a rune grid from lines, which must all have the same length.
+func FromLines(lines []string) (Grid[rune], error) {
+	if len(lines) == 0 || len(lines[0]) == 0 {
+		return Grid[rune]{}, fmt.Errorf("empty grid")
+	}
+	w := len([]rune(lines[0]))
+	g := Grid[rune]{W: w, H: len(lines), Cells: make([]rune, 0, w*len(lines))}
+	for y, line := range lines {
+		row := []rune(line)
+		if len(row) != w {
+			return Grid[rune]{}, fmt.Errorf("line %d: length %d, expected %d", y+1, len(row), w)
+		}
+		g.Cells = append(g.Cells, row...)
+	}
+	return g, nil
+}
+
+// Map converts each cell, like digits to ints.
+func Map[T, U comparable](g Grid[T], f func(T) U) Grid[U] {
+	u := New[U](g.W, g.H)
+	for i, v := range g.Cells {
+		u.Cells[i] = f(v)
+	}
+	return u
+}
+
+func (g Grid[T]) In(p Point) bool {
+	return 0 <= p.X && p.X < g.W && 0 <= p.Y && p.Y < g.H
+}
+
+// Index of the point in Cells. Point must be in the grid.
+func (g Grid[T]) Index(p Point) int {
+	return p.Y*g.W + p.X
+}
+
+// Point at index of Cells.
+func (g Grid[T]) Point(i int) Point {
+	return Point{i % g.W, i / g.W}
+}
+
+// At returns the cell, or zero value outside of the grid.
+func (g Grid[T]) At(p Point) T {
+	if !g.In(p) {
+		var zero T
+		return zero
+	}
+	return g.Cells[p.Y*g.W+p.X]
+}
+
+// Get returns the cell, and false outside of the grid.
+func (g Grid[T]) Get(p Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+	return g.Cells[p.Y*g.W+p.X], true
+}
+
+// Set changes the cell, and returns false if it's outside of the grid.
+func (g Grid[T]) Set(p Point, v T) bool {
+	if !g.In(p) {
+		return false
+	}
+	g.Cells[p.Y*g.W+p.X] = v
+	return true
+}
+
+// Fill sets all cells to v.
+func (g Grid[T]) Fill(v T) {
+	for i := range g.Cells {
+		g.Cells[i] = v
+	}
+}
+
+func (g Grid[T]) Clone() Grid[T] {
+	c := g
+	c.Cells = make([]T, len(g.Cells))
+	copy(c.Cells, g.Cells)
+	return c
+}
+
+// All iterates over points and cells, row by row.
+func (g Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, v := range g.Cells {
+			if !yield(Point{i % g.W, i / g.W}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors4 iterates over up to 4 orthogonal neighbors of p, which are in the grid.
+func (g Grid[T]) Neighbors4(p Point) iter.Seq[Point] {
+	return g.neighbors(p, Dirs4)
+}
+
+// Neighbors8 iterates over up to 8 neighbors of p, including diagonals, which are in the grid.
+func (g Grid[T]) Neighbors8(p Point) iter.Seq[Point] {
+	return g.neighbors(p, Dirs8)
+}
+
+func (g Grid[T]) neighbors(p Point, dirs []Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, d := range dirs {
+			np := p.Add(d)
+			if g.In(np) && !yield(np) {
+				return
+			}
+		}
+	}
+}
+
+// Find returns the first point with v, row by row.
+func (g Grid[T]) Find(v T) (Point, bool) {
+	for i, c := range g.Cells {
+		if c == v {
+			return g.Point(i), true
+		}
+	}
+	return Point{}, false
+}
+
+// FindAll returns all points with v, row by row.
+func (g Grid[T]) FindAll(v T) []Point {
+	var ps []Point
+	for i, c := range g.Cells {
+		if c == v {
+			ps = append(ps, g.Point(i))
+		}
+	}
+	return ps
+}
+
+// Count of cells with v.
+func (g Grid[T]) Count(v T) int {
+	var n int
+	for _, c := range g.Cells {
+		if c == v {
+			n++
+		}
+	}
+	return n
+}
+
+// Fprint prints the grid row by row, with cell formatting each cell.
+func (g Grid[T]) Fprint(w io.Writer, cell func(Point, T) string) error {
+	var sb strings.Builder
+	for y := range g.H {
+		for x := range g.W {
+			p := Point{x, y}
+			sb.WriteString(cell(p, g.Cells[y*g.W+x]))
+		}
+		sb.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// String prints runes as they are, and other cells with %v.
+func (g Grid[T]) String() string {
+	var sb strings.Builder
+	g.Fprint(&sb, func(_ Point, v T) string {
+		if r, ok := any(v).(rune); ok {
+			return string(r)
+		}
+		return fmt.Sprint(v)
+	})
+	return sb.String()
+}
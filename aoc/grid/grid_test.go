@@ -0,0 +1,71 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBounds(t *testing.T) {
+	g, err := FromLines([]string{"abc", "def"})
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		p    Point
+		want rune
+		in   bool
+	}{
+		{Point{0, 0}, 'a', true},
+		{Point{2, 0}, 'c', true},
+		{Point{0, 1}, 'd', true},
+		{Point{2, 1}, 'f', true},
+		{Point{3, 0}, 0, false},
+		{Point{0, 2}, 0, false},
+		{Point{-1, 0}, 0, false},
+		{Point{0, -1}, 0, false},
+		{Point{3, -1}, 0, false}, // would be 'f' by index
+	} {
+		assert.Equal(t, tt.in, g.In(tt.p), tt.p)
+		assert.Equal(t, tt.want, g.At(tt.p), tt.p)
+		v, ok := g.Get(tt.p)
+		assert.Equal(t, tt.want, v, tt.p)
+		assert.Equal(t, tt.in, ok, tt.p)
+
+		c := g.Clone()
+		assert.Equal(t, tt.in, c.Set(tt.p, 'x'), tt.p)
+		assert.Equal(t, 6-len(c.FindAll('x')), c.Count('a')+c.Count('b')+c.Count('c')+c.Count('d')+c.Count('e')+c.Count('f'))
+		if tt.in {
+			assert.Equal(t, 'x', c.At(tt.p))
+		} else {
+			assert.Equal(t, g.Cells, c.Cells, "nothing is set outside")
+		}
+	}
+	assert.Equal(t, "abc\ndef\n", g.String(), "clones don't change the grid")
+}
+
+func TestFromLines(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		lines []string
+		w, h  int
+		err   string
+	}{
+		{"square", []string{"ab", "cd"}, 2, 2, ""},
+		{"runes", []string{"┌┐", "└┘"}, 2, 2, ""},
+		{"shorter", []string{"abc", "de", "fgh"}, 0, 0, "line 2: length 2, expected 3"},
+		{"longer", []string{"ab", "cd", "efg"}, 0, 0, "line 3: length 3, expected 2"},
+		{"empty line", []string{"ab", ""}, 0, 0, "line 2: length 0, expected 2"},
+		{"no lines", nil, 0, 0, "empty grid"},
+		{"empty", []string{""}, 0, 0, "empty grid"},
+	} {
+		g, err := FromLines(tt.lines)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, tt.name)
+			continue
+		}
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.w, g.W, tt.name)
+		assert.Equal(t, tt.h, g.H, tt.name)
+		assert.Len(t, g.Cells, tt.w*tt.h, tt.name)
+	}
+}
@@ -0,0 +1,53 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+// Main is the whole main() of a day: it reads the input file given as the only argument
+// and prints answers of both parts with their times. Day specific flags must be defined before.
+func Main(day int) {
+	timeout := flag.Duration("timeout", 0, "time limit per part, 0 for none")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Println("Usage: go run . input.txt")
+		os.Exit(1)
+	}
+
+	puzzle, ok := Get(day)
+	if !ok {
+		fmt.Printf("Day %d is not registered\n", day)
+		os.Exit(1)
+	}
+
+	bs, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	parsed, err := puzzle.Parse(string(bs))
+	if err != nil {
+		fmt.Println(parse.WithFile(err, flag.Arg(0)))
+		os.Exit(1)
+	}
+	for part := 1; part <= 2; part++ {
+		timeStart := time.Now()
+		answer, err := puzzle.SolveTimeout(WithInput(context.Background(), flag.Arg(0)), parsed, part, *timeout)
+		if errors.Is(err, ErrNoPart) {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Part %d: %v\t\tin %v\n", part, err, time.Since(timeStart))
+			continue
+		}
+		fmt.Printf("Part %d: %v\t\tin %v\n", part, answer, time.Since(timeStart))
+	}
+}
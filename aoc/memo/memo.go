@@ -0,0 +1,93 @@
+// Package memo is a memoization cache, to be made per computation instead of global maps,
+// so solving several inputs in one process doesn't mix them up.
+package memo
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Cache of computed values. Safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	mu     sync.Mutex
+	values map[K]V
+	max    int
+	stats  Stats
+}
+
+// Stats of cache use.
+type Stats struct {
+	Hits, Misses int
+	Size         int
+	Evictions    int
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("size %d, hits %d, misses %d, evictions %d", s.Size, s.Hits, s.Misses, s.Evictions)
+}
+
+// New makes a cache of up to max values, or unbounded if max is 0.
+// When full, arbitrary values are evicted, which is fine for memoization, as they are just computed again.
+func New[K comparable, V any](max int) *Cache[K, V] {
+	return &Cache[K, V]{values: map[K]V{}, max: max}
+}
+
+// Get returns cached value, and counts hit or miss.
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[k]
+	if ok {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+	return v, ok
+}
+
+// Set caches the value, evicting another one if the cache is full.
+func (c *Cache[K, V]) Set(k K, v V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.values[k]; !ok && c.max > 0 && len(c.values) >= c.max {
+		for old := range c.values {
+			delete(c.values, old)
+			c.stats.Evictions++
+			break
+		}
+	}
+	c.values[k] = v
+}
+
+// Do returns cached value, or computes and caches it.
+// The lock is not held while computing, so f can use the cache too, like in recursion.
+func (c *Cache[K, V]) Do(k K, f func() V) V {
+	if v, ok := c.Get(k); ok {
+		return v
+	}
+	v := f()
+	c.Set(k, v)
+	return v
+}
+
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.values)
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Size = len(c.values)
+	return s
+}
+
+// Clear removes all values, and resets stats.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.values)
+	c.stats = Stats{}
+}
@@ -0,0 +1,91 @@
+package memo
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEviction(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		max, sets int
+		size      int
+		evictions int
+	}{
+		{"unbounded", 0, 100, 100, 0},
+		{"below max", 10, 5, 5, 0},
+		{"at max", 10, 10, 10, 0},
+		{"over max", 10, 25, 10, 15},
+		{"one", 1, 3, 1, 2},
+	} {
+		c := New[int, int](tt.max)
+		for i := range tt.sets {
+			c.Set(i, i*i)
+		}
+		assert.Equal(t, tt.size, c.Len(), tt.name)
+		s := c.Stats()
+		assert.Equal(t, tt.size, s.Size, tt.name)
+		assert.Equal(t, tt.evictions, s.Evictions, tt.name)
+
+		// the last value is kept, and setting what's cached already evicts nothing
+		v, ok := c.Get(tt.sets - 1)
+		assert.True(t, ok, tt.name)
+		assert.Equal(t, (tt.sets-1)*(tt.sets-1), v, tt.name)
+		c.Set(tt.sets-1, 0)
+		assert.Equal(t, tt.evictions, c.Stats().Evictions, tt.name)
+	}
+}
+
+func TestDo(t *testing.T) {
+	c := New[int, int](0)
+	var fib func(n int) int
+	calls := 0
+	fib = func(n int) int {
+		return c.Do(n, func() int {
+			calls++
+			if n < 2 {
+				return n
+			}
+			return fib(n-1) + fib(n-2) // uses the cache while computing
+		})
+	}
+	assert.Equal(t, 102334155, fib(40))
+	assert.Equal(t, 41, calls)
+	assert.Equal(t, Stats{Hits: 38, Misses: 41, Size: 41}, c.Stats())
+
+	c.Clear()
+	assert.Equal(t, Stats{}, c.Stats())
+	_, ok := c.Get(40)
+	assert.False(t, ok)
+}
+
+func TestConcurrent(t *testing.T) {
+	const workers, keys = 8, 100
+	for _, max := range []int{0, 10} {
+		c := New[int, int](max)
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for k := range keys {
+					v := c.Do(k, func() int { return k * 2 })
+					assert.Equal(t, k*2, v)
+					if v, ok := c.Get(k); ok {
+						assert.Equal(t, k*2, v)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		s := c.Stats()
+		assert.Equal(t, 2*workers*keys, s.Hits+s.Misses, "each Do and Get is counted once")
+		if max > 0 {
+			assert.LessOrEqual(t, c.Len(), max)
+		} else {
+			assert.Equal(t, keys, c.Len())
+		}
+	}
+}
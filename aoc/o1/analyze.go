@@ -0,0 +1,330 @@
+package o1
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+)
+
+// Kind of a finding.
+type Kind string
+
+const (
+	Hardcoded   Kind = "hardcoded answer" // literal equal to an answer
+	InputSwitch Kind = "switch on input"  // cases with content or size of an input
+	Stub        Kind = "stub"             // TODO comment, or a body that only returns a constant or panics
+	UnusedInput Kind = "unused input"     // input is never read, or a parameter or read data is never used
+)
+
+// Finding is a suspicious place in the code of an attempt: the prompt forbids hardcoded answers and stubs.
+type Finding struct {
+	Kind Kind   `json:"kind"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%d: %s: %s", f.Line, f.Kind, f.Text)
+}
+
+// MinAnswer is the length of the shortest answer looked for in literals.
+// Shorter numbers are everywhere, as sizes, directions and loop bounds.
+const MinAnswer = 3
+
+// Analyze looks for hardcoded answers of the day, switches on input content, stubs and unused inputs
+// in the code of the attempt. Code that doesn't parse is analyzed as far as it goes.
+func Analyze(root string, a Attempt) ([]Finding, error) {
+	answers, err := aoc.LoadAnswers(root, a.Day)
+	if err != nil {
+		return nil, err
+	}
+	var known []string
+	for _, parts := range answers {
+		for _, answer := range parts {
+			if len(answer) >= MinAnswer && !slices.Contains(known, answer) {
+				known = append(known, answer)
+			}
+		}
+	}
+	inputs, err := aoc.Inputs(root, a.Day)
+	if err != nil {
+		return nil, err
+	}
+	var contents []string
+	for _, input := range inputs {
+		bs, err := os.ReadFile(filepath.Join(root, aoc.Dir(a.Day), filepath.FromSlash(input)))
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, string(bs))
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Join(root, filepath.FromSlash(a.Path)), nil, parser.ParseComments)
+	if file == nil {
+		return nil, err
+	}
+	az := analyzer{fset: fset, file: file, answers: known, inputs: contents}
+	az.hardcoded()
+	az.switches()
+	az.stubs()
+	az.unused()
+	slices.SortStableFunc(az.findings, func(a, b Finding) int { return a.Line - b.Line })
+	return az.findings, nil
+}
+
+type analyzer struct {
+	fset     *token.FileSet
+	file     *ast.File
+	answers  []string
+	inputs   []string // contents
+	findings []Finding
+}
+
+func (az *analyzer) add(kind Kind, pos token.Pos, format string, args ...any) {
+	az.findings = append(az.findings, Finding{Kind: kind, Line: az.fset.Position(pos).Line, Text: fmt.Sprintf(format, args...)})
+}
+
+// hardcoded finds number literals equal to answers, and string literals with them, like "Part 1: 1234".
+func (az *analyzer) hardcoded() {
+	ast.Inspect(az.file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.BasicLit)
+		if !ok {
+			return true
+		}
+		switch lit.Kind {
+		case token.INT:
+			value := constant.MakeFromLiteral(lit.Value, lit.Kind, 0).ExactString()
+			if slices.Contains(az.answers, value) {
+				az.add(Hardcoded, lit.Pos(), "%s", lit.Value)
+			}
+		case token.STRING:
+			s, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+			for _, answer := range az.answers {
+				if reWord(answer).MatchString(s) {
+					az.add(Hardcoded, lit.Pos(), "%s in %s", answer, lit.Value)
+					break
+				}
+			}
+		}
+		return true
+	})
+}
+
+// reWord matches the answer as a whole word, not a part of a longer number.
+func reWord(answer string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(answer) + `($|[^\w.]|\.\D|\.$)`)
+}
+
+// switches finds cases with content of an input, or a line of it, and switches on len() with cases
+// of the number of lines or bytes of an input: ways to tell the sample from the real input.
+func (az *analyzer) switches() {
+	ast.Inspect(az.file, func(n ast.Node) bool {
+		sw, ok := n.(*ast.SwitchStmt)
+		if !ok {
+			return true
+		}
+		call, _ := sw.Tag.(*ast.CallExpr)
+		onLen := call != nil && isIdent(call.Fun, "len")
+		for _, stmt := range sw.Body.List {
+			for _, expr := range stmt.(*ast.CaseClause).List {
+				lit, ok := expr.(*ast.BasicLit)
+				if !ok {
+					continue
+				}
+				switch {
+				case lit.Kind == token.STRING && az.inInput(lit.Value):
+					az.add(InputSwitch, lit.Pos(), "case %s", short(lit.Value))
+				case lit.Kind == token.INT && onLen && az.isSize(lit.Value):
+					az.add(InputSwitch, lit.Pos(), "case %s of len()", lit.Value)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// inInput reports if the string literal is an input, or a line of it.
+func (az *analyzer) inInput(value string) bool {
+	s, err := strconv.Unquote(value)
+	s = strings.TrimSpace(s)
+	if err != nil || len(s) < 4 {
+		return false
+	}
+	for _, content := range az.inputs {
+		if s == strings.TrimSpace(content) || slices.Contains(strings.Split(content, "\n"), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSize reports if the int literal is a number of lines or bytes of an input.
+func (az *analyzer) isSize(value string) bool {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 10 {
+		return false
+	}
+	for _, content := range az.inputs {
+		lines := strings.Count(strings.TrimRight(content, "\n"), "\n") + 1
+		if n == lines || n == len(content) || n == len(strings.TrimSpace(content)) {
+			return true
+		}
+	}
+	return false
+}
+
+// reTODO matches notes of unfinished code, in English and in Russian, as chats are in Russian.
+var reTODO = regexp.MustCompile(`(?i)\bTODO\b|\bFIXME\b|not implemented|implement (this|here|later)|(не|нужно|необходимо)\s+реализ|реализуйте|заглушк`)
+
+// stubs finds TODO comments in function bodies, and functions that only return constants or panic.
+func (az *analyzer) stubs() {
+	for _, decl := range az.file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		for _, group := range az.file.Comments {
+			if group.Pos() > fn.Body.Lbrace && group.End() < fn.Body.Rbrace && reTODO.MatchString(group.Text()) {
+				az.add(Stub, group.Pos(), "%s: %s", fn.Name.Name, short(strings.TrimSpace(group.Text())))
+			}
+		}
+		if fn.Type.Params.NumFields() == 0 || len(fn.Body.List) != 1 {
+			continue
+		}
+		switch stmt := fn.Body.List[0].(type) {
+		case *ast.ReturnStmt:
+			if len(stmt.Results) > 0 && !slices.ContainsFunc(stmt.Results, func(e ast.Expr) bool { return !isConst(e) }) {
+				az.add(Stub, fn.Pos(), "%s only returns a constant", fn.Name.Name)
+			}
+		case *ast.ExprStmt:
+			if call, ok := stmt.X.(*ast.CallExpr); ok && isIdent(call.Fun, "panic") {
+				az.add(Stub, fn.Pos(), "%s only panics", fn.Name.Name)
+			}
+		}
+	}
+}
+
+// isConst reports if the expression is a literal, nil, true or false.
+func isConst(e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		return e.Name == "nil" || e.Name == "true" || e.Name == "false"
+	case *ast.UnaryExpr:
+		return isConst(e.X)
+	}
+	return false
+}
+
+// reading calls, the results of which are input data.
+var reading = []string{"os.ReadFile", "io.ReadAll", "os.Open", "bufio.NewScanner", "bufio.NewReader"}
+
+// unused finds programs that don't read any input, function parameters that are never used,
+// and input data read into variables that are never used. "_ = x" is not a use.
+func (az *analyzer) unused() {
+	var reads bool
+	ast.Inspect(az.file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if name := selector(n); name == "os.Args" || name == "os.Stdin" {
+				reads = true
+			}
+		case *ast.BasicLit:
+			if s, err := strconv.Unquote(n.Value); err == nil && n.Kind == token.STRING && strings.HasSuffix(s, ".txt") {
+				reads = true
+			}
+		}
+		return true
+	})
+	if !reads {
+		az.add(UnusedInput, az.file.Name.Pos(), "no os.Args, os.Stdin or .txt file")
+	}
+
+	for _, decl := range az.file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || len(fn.Body.List) == 0 {
+			continue
+		}
+		var vars []*ast.Ident
+		for _, field := range fn.Type.Params.List {
+			vars = append(vars, field.Names...)
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if assign, ok := n.(*ast.AssignStmt); ok && assign.Tok == token.DEFINE && len(assign.Rhs) == 1 {
+				if call, ok := assign.Rhs[0].(*ast.CallExpr); ok && slices.Contains(reading, selector(call.Fun)) {
+					if id, ok := assign.Lhs[0].(*ast.Ident); ok { // code that doesn't parse can have anything there
+						vars = append(vars, id)
+					}
+				}
+			}
+			return true
+		})
+		for _, v := range vars {
+			if v.Name != "_" && !used(fn.Body, v) {
+				az.add(UnusedInput, v.Pos(), "%s of %s is never used", v.Name, fn.Name.Name)
+			}
+		}
+	}
+}
+
+// used reports if the variable is used in the body, other than where it's defined, or assigned to _.
+func used(body *ast.BlockStmt, v *ast.Ident) bool {
+	var found bool
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if !slices.ContainsFunc(n.Lhs, func(e ast.Expr) bool { return !isIdent(e, "_") }) {
+				return false
+			}
+		case *ast.Ident:
+			found = n != v && n.Name == v.Name // shadowing is rare enough
+		}
+		return true
+	})
+	return found
+}
+
+func isIdent(e ast.Expr, name string) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+// selector is the name of a package member, like "os.Args", or "".
+func selector(e ast.Expr) string {
+	sel, ok := e.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return pkg.Name + "." + sel.Sel.Name
+}
+
+// short is the first line of the string, cut to a reasonable length.
+func short(s string) string {
+	s, _, _ = strings.Cut(s, "\n")
+	if rs := []rune(s); len(rs) > 60 {
+		return string(rs[:57]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,83 @@
+package o1
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cheat = `package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+func part2(lines []string) int {
+	// TODO: part 2
+	return 0
+}
+
+func count(grid []string) int {
+	panic("later")
+}
+
+func main() {
+	data, _ := os.ReadFile(os.Args[1])
+	lines := strings.Split(string(data), "\\n")
+	switch lines[0] {
+	case "1 2 3":
+		fmt.Println("Part 1:", 1234)
+	}
+	switch len(lines) {
+	case 12:
+		fmt.Println("Part 1: 0x4d2 is 1234")
+	}
+	fmt.Println(part2(lines), count(nil))
+}
+`
+
+func TestAnalyze(t *testing.T) {
+	root := newRoot(t)
+	path := filepath.Join(root, "99", "o1", "v7", "v7.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(cheat), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "99", "answers.json"),
+		[]byte(`{"input.txt": {"1": "1234", "2": "7"}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "99", "input2.txt"), []byte(strings.Repeat("1\n", 12)), 0644))
+
+	findings, err := Analyze(root, Attempt{Day: 99, Version: 7, Path: "99/o1/v7/v7.go"})
+	require.NoError(t, err)
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.String())
+	}
+	assert.Equal(t, []string{
+		"9: stub: part2 only returns a constant",
+		"9: unused input: lines of part2 is never used",
+		"10: stub: part2: TODO: part 2",
+		"14: stub: count only panics",
+		"14: unused input: grid of count is never used",
+		`22: switch on input: case "1 2 3"`,
+		"23: hardcoded answer: 1234",
+		"26: switch on input: case 12 of len()",
+		`27: hardcoded answer: 1234 in "Part 1: 0x4d2 is 1234"`,
+	}, got)
+
+	findings, err = Analyze(root, Attempt{Day: 99, Version: 1, Path: "99/o1/v1.go"})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "input of main is never used", findings[0].Text, "_ = input is not a use")
+
+	broken := "package main\n\nimport \"os\"\n\nfunc main() {\n\tin.data := os.ReadFile(\"input.txt\")\n\tfor {\n}\n"
+	path = filepath.Join(root, "99", "o1", "v8.go_invalid")
+	require.NoError(t, os.WriteFile(path, []byte(broken), 0644))
+	findings, err = Analyze(root, Attempt{Day: 99, Version: 8, Path: "99/o1/v8.go_invalid"})
+	require.NoError(t, err, "doesn't parse, but is analyzed as far as it goes")
+	assert.Empty(t, findings)
+}
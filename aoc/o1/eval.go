@@ -0,0 +1,289 @@
+package o1
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/sandbox"
+)
+
+// Outcome of an attempt, a part, or a run.
+type Outcome string
+
+const (
+	Correct      Outcome = "correct"
+	Incorrect    Outcome = "incorrect"
+	Timeout      Outcome = "timeout"
+	Panic        Outcome = "panic"
+	Failed       Outcome = "error" // exited with non-zero code, without panic
+	CompileError Outcome = "compile error"
+	Unchecked    Outcome = "unchecked" // no inputs with answers to check it on, neither correct nor not
+)
+
+// Outcomes from the best to the worst. Unchecked is none of them.
+var Outcomes = []Outcome{Correct, Incorrect, Failed, Timeout, Panic, CompileError}
+
+// Kind is the outcome without the part, like timeout for "timeout p2".
+func (o Outcome) Kind() Outcome {
+	kind, part, ok := strings.Cut(string(o), " p")
+	if ok && (part == "1" || part == "2") {
+		return Outcome(kind)
+	}
+	return o
+}
+
+func rank(o Outcome) int {
+	i := slices.Index(Outcomes, o.Kind())
+	if i < 0 {
+		return len(Outcomes)
+	}
+	return i
+}
+
+// Options of Eval.
+type Options struct {
+	Timeout      time.Duration // per run
+	BuildTimeout time.Duration
+	Samples      bool     // run sample files too, not just inputs
+	Inputs       []string // only these files, if given
+}
+
+// Result of an attempt on all inputs with recorded answers.
+type Result struct {
+	Attempt
+	// Outcome is the worst of parts, with the part if the other one is better, like "incorrect p1" or "timeout p2".
+	Outcome  Outcome         `json:"outcome"`
+	Parts    map[int]Outcome `json:"parts,omitempty"`
+	Build    string          `json:"build,omitempty"` // output of go build, if it failed
+	Runs     []Run           `json:"runs,omitempty"`
+	Findings []Finding       `json:"findings,omitempty"` // of Analyze
+}
+
+// Run is a run of the attempt on one input.
+type Run struct {
+	Input    string          `json:"input"`
+	Outcome  Outcome         `json:"outcome"` // of the program, correct if it exited in time without errors
+	Parts    map[int]Outcome `json:"parts"`
+	Want     map[int]string  `json:"want"`
+	ExitCode int             `json:"exit_code"`
+	Signal   string          `json:"signal,omitempty"`
+	Time     time.Duration   `json:"time_ns"`
+	MaxRSS   int64           `json:"max_rss,omitempty"`
+	Stdout   string          `json:"stdout,omitempty"`
+	Stderr   string          `json:"stderr,omitempty"`
+}
+
+// Eval builds the attempt in a temp dir, runs it there on a copy of each input of the day
+// with recorded answers, and compares what it prints with them.
+func Eval(ctx context.Context, root string, a Attempt, opts Options) Result {
+	res := Result{Attempt: a, Parts: map[int]Outcome{}}
+	answers, err := aoc.LoadAnswers(root, a.Day)
+	if err != nil {
+		res.Outcome, res.Build = Failed, err.Error()
+		return res
+	}
+	inputs, err := aoc.Inputs(root, a.Day)
+	if err != nil {
+		res.Outcome, res.Build = Failed, err.Error()
+		return res
+	}
+	res.Findings, _ = Analyze(root, a) // a file that can't be read fails to build anyway
+
+	dir, err := os.MkdirTemp("", "o1eval")
+	if err != nil {
+		res.Outcome, res.Build = Failed, err.Error()
+		return res
+	}
+	defer os.RemoveAll(dir)
+	bin, err := Build(ctx, filepath.Join(root, filepath.FromSlash(a.Path)), dir, opts.BuildTimeout)
+	if err != nil {
+		res.Outcome, res.Build = CompileError, err.Error()
+		return res
+	}
+
+	for _, input := range inputs {
+		if !opts.Samples && strings.HasPrefix(filepath.Base(input), "sample") {
+			continue
+		}
+		if len(opts.Inputs) > 0 && !slices.Contains(opts.Inputs, input) {
+			continue
+		}
+		want := map[int]string{}
+		for _, part := range []int{1, 2} {
+			if a.Part() != 0 && a.Part() != part {
+				continue // the run asked for the other part only
+			}
+			if answer, ok := answers.Get(input, part); ok {
+				want[part] = answer
+			}
+		}
+		if len(want) == 0 {
+			continue
+		}
+		// a copy, as some attempts write files next to the input, or even over it
+		path := filepath.Join(dir, filepath.Base(input))
+		bs, err := os.ReadFile(filepath.Join(root, aoc.Dir(a.Day), filepath.FromSlash(input)))
+		if err == nil {
+			err = os.WriteFile(path, bs, 0644)
+		}
+		if err != nil {
+			res.Outcome, res.Build = Failed, err.Error()
+			return res
+		}
+		out := Exec(ctx, dir, bin, path, opts.Timeout)
+		run := Run{Input: input, Outcome: out.Outcome(), Parts: map[int]Outcome{}, Want: want,
+			ExitCode: out.ExitCode, Signal: out.Signal, Time: out.Time, MaxRSS: out.MaxRSS, Stdout: out.Stdout, Stderr: out.Stderr}
+		for part, answer := range want {
+			run.Parts[part] = run.Outcome
+			if Printed(out.Stdout, part, answer) {
+				run.Parts[part] = Correct
+			} else if run.Outcome == Correct {
+				run.Parts[part] = Incorrect
+			}
+			if prev, ok := res.Parts[part]; !ok || rank(run.Parts[part]) > rank(prev) {
+				res.Parts[part] = run.Parts[part]
+			}
+		}
+		res.Runs = append(res.Runs, run)
+	}
+	res.Outcome = overall(res.Parts)
+	return res
+}
+
+// overall is the worst outcome of parts, with the part it's about, if the other part is better.
+func overall(parts map[int]Outcome) Outcome {
+	var worst Outcome
+	var with []int
+	for _, part := range []int{1, 2} {
+		o, ok := parts[part]
+		switch {
+		case !ok:
+		case worst == "" || rank(o) > rank(worst):
+			worst, with = o, []int{part}
+		case rank(o) == rank(worst):
+			with = append(with, part)
+		}
+	}
+	if worst == "" {
+		return Unchecked
+	}
+	if worst != Correct && len(with) == 1 && len(parts) == 2 {
+		return Outcome(fmt.Sprintf("%s p%d", worst, with[0]))
+	}
+	return worst
+}
+
+// BuildError is the output of go build that failed.
+type BuildError struct {
+	Output string
+}
+
+func (e *BuildError) Error() string {
+	return e.Output
+}
+
+// Build compiles the single file program as the main package of a module in dir, and returns the binary.
+func Build(ctx context.Context, src, dir string, timeout time.Duration) (string, error) {
+	code, err := os.ReadFile(src)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), code, 0644); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module attempt\n\ngo 1.23\n"), 0644); err != nil {
+		return "", err
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	bin := filepath.Join(dir, "attempt")
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", bin, ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local")
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return "", &BuildError{Output: fmt.Sprintf("build timeout after %v", timeout)}
+	}
+	if err != nil {
+		// paths of the temp dir say nothing
+		output := strings.ReplaceAll(string(out), dir+string(filepath.Separator), "")
+		output = strings.TrimPrefix(output, "# attempt\n")
+		return "", &BuildError{Output: strings.TrimSpace(output)}
+	}
+	return bin, nil
+}
+
+// Limits of each run, besides the time: attempts are untrusted, and some write files, or eat all memory.
+// CPU time is limited to the timeout on each core.
+var Limits = sandbox.Limits{Memory: 8 << 30, Files: 256, FileSize: 64 << 20}
+
+// Output of the program.
+type Output struct {
+	sandbox.Result
+}
+
+// Outcome of the run, regardless of answers: correct if the program exited in time without errors.
+func (o Output) Outcome() Outcome {
+	switch {
+	case o.Timeout:
+		return Timeout
+	case (o.ExitCode == 2 && strings.Contains(o.Stderr, "panic: ")) || strings.Contains(o.Stderr, "fatal error: "):
+		return Panic
+	case o.ExitCode != 0 || o.Err != "":
+		return Failed
+	}
+	return Correct
+}
+
+// Exec runs the program in the sandbox in dir, with the input file as the only argument,
+// killing it after timeout, if it's not 0.
+func Exec(ctx context.Context, dir, bin, input string, timeout time.Duration) Output {
+	limits := Limits
+	limits.Wall = timeout
+	limits.CPU = timeout * time.Duration(runtime.NumCPU())
+	return Output{sandbox.Run(ctx, dir, limits, bin, input)}
+}
+
+var rePart = map[int]*regexp.Regexp{
+	1: regexp.MustCompile(`(?i)part\s*(1|one)\b|\bp1\b|част\S*\s*(1|перв)|перв\S*\s*част`),
+	2: regexp.MustCompile(`(?i)part\s*(2|two)\b|\bp2\b|част\S*\s*(2|втор)|втор\S*\s*част`),
+}
+
+// reDuration matches printed times, like 1.5ms, 2m3.4s or 0.0012s.
+var reDuration = regexp.MustCompile(`\b(\d+(\.\d+)?(ns|µs|us|ms|s|m|h))+`)
+
+// Printed reports if the output has the answer. Output formats are all different, so the answer
+// is looked for in lines that mention the part with some number, like "Part 1: 42" or "Часть Первая Ответ: 42",
+// or anywhere, if no line does. Printed times are skipped, and the answer must not be a part of a longer number or list.
+func Printed(output string, part int, answer string) bool {
+	reAnswer := regexp.MustCompile(`(^|[^\w,.])` + regexp.QuoteMeta(answer) + `($|[^\w,.]|[.,]\D|[.,]$)`)
+	lines := strings.Split(reDuration.ReplaceAllString(output, ""), "\n")
+	var marked []string
+	for _, line := range lines {
+		// without the marker, so "2" is not found in "Part 2: 3"
+		if rest := rePart[part].ReplaceAllString(line, ""); rest != line && strings.ContainsAny(rest, "0123456789") {
+			marked = append(marked, rest)
+		}
+	}
+	if len(marked) == 0 {
+		marked = lines
+	}
+	for _, line := range marked {
+		if reAnswer.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package o1
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds programs")
+	}
+	root := newRoot(t)
+	found, err := Find(root, 99)
+	require.NoError(t, err)
+	opts := Options{Timeout: time.Second, BuildTimeout: time.Minute}
+	want := []Outcome{Correct, "incorrect p2", CompileError, "panic p2", "timeout p2", Incorrect, "correct", Incorrect}
+	for i, a := range found {
+		res := Eval(context.Background(), root, a, opts)
+		assert.Equal(t, want[i], res.Outcome, a.String())
+		if res.Outcome == CompileError {
+			assert.Contains(t, res.Build, "declared and not used: unused")
+			continue
+		}
+		require.Len(t, res.Runs, 1, "samples are not run")
+		assert.Equal(t, "input.txt", res.Runs[0].Input)
+	}
+
+	bs, err := os.ReadFile(filepath.Join(root, "99", "input.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1 2 3\n", string(bs), "input is not overwritten by v6")
+
+	opts.Samples = true
+	res := Eval(context.Background(), root, found[6], opts)
+	assert.Equal(t, Incorrect, res.Outcome, "41 is not 1 of the sample")
+	assert.Len(t, res.Runs, 2)
+
+	opts.Inputs = []string{"input2.txt"}
+	res = Eval(context.Background(), root, found[0], opts)
+	assert.Equal(t, Unchecked, res.Outcome, "no answers of input2.txt")
+	assert.Empty(t, res.Runs)
+}
@@ -0,0 +1,84 @@
+package o1
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+)
+
+var reCodeBlock = regexp.MustCompile("(?s)```[a-z]*[ \t]*\n(.*?)```")
+
+// ExtractGo returns the program from the reply of the model: the last code block with package main.
+func ExtractGo(reply string) (string, bool) {
+	blocks := reCodeBlock.FindAllStringSubmatch(reply, -1)
+	for _, block := range slices.Backward(blocks) {
+		if strings.Contains(block[1], "package main") {
+			return block[1], true
+		}
+	}
+	return "", false
+}
+
+// Feedback is what the model gets back after the attempt, the way it was written by hand:
+// the command and its output, and what's wrong with the answers. Samples go first,
+// and runs stop at the first failed one. With answers, expected ones are given too.
+func Feedback(res Result, answers bool) string {
+	rel := strings.TrimPrefix(res.Path, aoc.Dir(res.Day)+"/")
+	cmd := "./" + rel
+	if dir := path.Dir(rel); path.Base(dir) == fmt.Sprintf("v%d", res.Version) {
+		cmd = "./" + dir
+	}
+	var b strings.Builder
+	if res.Outcome == CompileError {
+		fmt.Fprintf(&b, "➜ go run %s\n", cmd)
+		fmt.Fprintln(&b, strings.ReplaceAll(res.Build, "./main.go", rel))
+		return b.String()
+	}
+	runs := slices.Clone(res.Runs)
+	slices.SortStableFunc(runs, func(a, b Run) int {
+		return strings.Compare(path.Base(b.Input)[:1], path.Base(a.Input)[:1]) // s for samples before i
+	})
+	for i, run := range runs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "➜ go run %s %s\n", cmd, path.Base(run.Input))
+		b.WriteString(run.Stdout)
+		b.WriteString(run.Stderr)
+		if !strings.HasSuffix(run.Stdout+run.Stderr, "\n") {
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		failed := false
+		switch run.Outcome {
+		case Timeout:
+			fmt.Fprintf(&b, "Программа не завершилась за %v.\n", run.Time.Round(100*time.Millisecond))
+			failed = true
+		case Panic, Failed:
+			fmt.Fprintf(&b, "exit status %d\n", run.ExitCode)
+			failed = true
+		}
+		for _, part := range []int{1, 2} {
+			want, ok := run.Want[part]
+			if !ok || run.Parts[part] != Incorrect {
+				continue
+			}
+			failed = true
+			fmt.Fprintf(&b, "Ответ на %s часть неверный.", []string{"", "первую", "вторую"}[part])
+			if answers {
+				fmt.Fprintf(&b, " Ожидаемый ответ: %s", want)
+			}
+			b.WriteString("\n")
+		}
+		if failed {
+			break
+		}
+		b.WriteString("Ответ верный.\n")
+	}
+	return b.String()
+}
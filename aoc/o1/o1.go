@@ -0,0 +1,101 @@
+// Package o1 finds, builds and runs programs written by models, kept in NN/o1 of each day.
+//
+// Each attempt is a single file program, like 17/o1/v3/v3_incorrect.go, with the prompt and
+// feedback it got in v3.txt next to it. Attempts of the first days are right in NN/o1, and
+// days with several chats have them in NN/o1/run2/vN or NN/o1/run2-o1-mini-p1/vN.
+package o1
+
+import (
+	"cmp"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+)
+
+// Attempt is a program written by the model.
+type Attempt struct {
+	Day     int    `json:"day"`
+	Run     string `json:"run,omitempty"` // folder of the run, like "run2-o1-mini-p1", empty for the main one
+	Version int    `json:"version"`
+	Path    string `json:"path"`              // relative to the repository root, with slashes
+	Label   string `json:"label,omitempty"`   // given by hand in the file name, like "incorrect_p1" or "timeout_p2"
+	Invalid bool   `json:"invalid,omitempty"` // named .go_invalid, as it didn't compile
+}
+
+// Model is o1, unless the run is named after another one, like run1-o1-mini.
+func (a Attempt) Model() string {
+	for _, model := range []string{"o1-mini", "o1-preview"} {
+		if strings.Contains(a.Run, model) {
+			return model
+		}
+	}
+	return "o1"
+}
+
+// Part is the only part the run asked for, like 1 for run2-o1-mini-p1, or 0 for both.
+func (a Attempt) Part() int {
+	m := reRunPart.FindStringSubmatch(a.Run)
+	if m == nil {
+		return 0
+	}
+	part, _ := strconv.Atoi(m[1])
+	return part
+}
+
+var reRunPart = regexp.MustCompile(`\bp([12])$`)
+
+// String is like "17 v3" or "21 run2-o1-mini-p1/v5".
+func (a Attempt) String() string {
+	if a.Run == "" {
+		return aoc.Dir(a.Day) + " v" + strconv.Itoa(a.Version)
+	}
+	return aoc.Dir(a.Day) + " " + a.Run + "/v" + strconv.Itoa(a.Version)
+}
+
+// reAttempt matches file names of attempts: v3.go, v11_incorrect_p1.go, v1.go_invalid.
+var reAttempt = regexp.MustCompile(`^v(\d+)(?:_(.*))?\.go(_invalid)?$`)
+
+// Find returns attempts of the day, ordered by run and version. A day without NN/o1 has none.
+func Find(root string, day int) ([]Attempt, error) {
+	dir := filepath.Join(root, aoc.Dir(day), "o1")
+	var attempts []Attempt
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		m := reAttempt.FindStringSubmatch(d.Name())
+		if d.IsDir() || m == nil {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		version, _ := strconv.Atoi(m[1])
+		a := Attempt{Day: day, Version: version, Label: m[2], Invalid: m[3] != ""}
+		a.Path = filepath.ToSlash(filepath.Join(aoc.Dir(day), "o1", rel))
+		// folders are the version folder, if any, under the run folder, if any
+		parts := strings.Split(filepath.ToSlash(filepath.Dir(rel)), "/")
+		if parts[len(parts)-1] == "v"+m[1] {
+			parts = parts[:len(parts)-1]
+		}
+		if len(parts) > 0 && parts[0] != "." {
+			a.Run = strings.Join(parts, "/")
+		}
+		attempts = append(attempts, a)
+		return nil
+	})
+	slices.SortFunc(attempts, func(a, b Attempt) int {
+		return cmp.Or(cmp.Compare(a.Run, b.Run), cmp.Compare(a.Version, b.Version), cmp.Compare(a.Path, b.Path))
+	})
+	return attempts, err
+}
@@ -0,0 +1,61 @@
+package o1
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func program(body string) string {
+	return "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n\t\"time\"\n)\n\nvar _ = time.Now\n\nfunc main() {\n\tinput, _ := os.ReadFile(os.Args[1])\n\t_ = input\n" + body + "}\n"
+}
+
+var attempts = map[string]string{
+	"v1.go":                        program("\tfmt.Println(\"Часть Первая Ответ:\", 42)\n\tfmt.Println(\"Время выполнения Части Первой: 42ms\")\n\tfmt.Printf(\"Part 2: %d\\n\", 7)\n"),
+	"v2/v2_incorrect.go":           program("\tfmt.Println(\"Part 1: 42 (time 0.0420s)\")\n\tfmt.Println(\"Part 2: 8\")\n"),
+	"v3/v3.go_invalid":             program("\tunused := 1\n"),
+	"v4/v4.go":                     program("\tfmt.Println(\"Part 1: 42\")\n\tvar m map[int]int\n\tm[1] = 7\n"),
+	"v5/v5_slow.go":                program("\tfmt.Println(42)\n\ttime.Sleep(time.Minute)\n\tfmt.Println(7)\n"),
+	"v6/v6.go":                     program("\tos.WriteFile(os.Args[1], []byte(\"overwritten\"), 0644)\n\tfmt.Println(\"1420\\n7.42\\n\")\n"),
+	"run2-o1-mini-p1/v1/v1.go":     program("\tfmt.Println(42)\n"),
+	"run2-o1-mini-p1/v2/v2_wut.go": program("\tfmt.Println(\"Part 1:\", 41)\n"),
+}
+
+func newRoot(t *testing.T) string {
+	root := t.TempDir()
+	for name, src := range attempts {
+		path := filepath.Join(root, "99", "o1", filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, "99", "input.txt"), []byte("1 2 3\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "99", "sample.txt"), []byte("1\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "99", "answers.json"),
+		[]byte(`{"input.txt": {"1": "42", "2": "7"}, "sample.txt": {"1": "1"}}`), 0644))
+	return root
+}
+
+func TestFind(t *testing.T) {
+	root := newRoot(t)
+	found, err := Find(root, 99)
+	require.NoError(t, err)
+	var names []string
+	for _, a := range found {
+		names = append(names, a.String()+" "+a.Label)
+	}
+	assert.Equal(t, []string{"99 v1 ", "99 v2 incorrect", "99 v3 ", "99 v4 ", "99 v5 slow", "99 v6 ",
+		"99 run2-o1-mini-p1/v1 ", "99 run2-o1-mini-p1/v2 wut"}, names)
+	assert.True(t, found[2].Invalid)
+	assert.Equal(t, "o1", found[0].Model())
+	assert.Equal(t, 0, found[0].Part())
+	assert.Equal(t, "o1-mini", found[6].Model())
+	assert.Equal(t, 1, found[6].Part())
+	assert.Equal(t, "99/o1/run2-o1-mini-p1/v1/v1.go", found[6].Path)
+
+	found, err = Find(root, 98)
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+}
@@ -0,0 +1,173 @@
+package o1
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+)
+
+// PromptsDir in the repository root has prompt templates, named like ru.5.tmpl: the name and the version.
+// Templates get PromptVars, and the task of the day is added after them.
+const PromptsDir = "o1/prompts"
+
+// DefaultPrompt is the name of the prompt to use, in its latest version.
+const DefaultPrompt = "ru"
+
+// PromptVars are variables of prompt templates.
+type PromptVars struct {
+	Day       int           `json:"day"`
+	Part      int           `json:"part,omitempty"` // the only part to solve, 0 for both
+	TimeLimit time.Duration `json:"time_limit_ns"`
+	Language  string        `json:"language"`
+	Model     string        `json:"model"`
+}
+
+// Meta of a version, in vN.meta.json next to vN.txt: the prompt that started the chat, and its variables.
+type Meta struct {
+	Prompt        string `json:"prompt"`
+	PromptVersion int    `json:"prompt_version"`
+	PromptVars
+}
+
+// MetaPath is the path of vN.meta.json for vN.txt.
+func MetaPath(pathTxt string) string {
+	return strings.TrimSuffix(pathTxt, ".txt") + ".meta.json"
+}
+
+// LoadMeta reads vN.meta.json.
+func LoadMeta(path string) (Meta, error) {
+	var m Meta
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return m, err
+	}
+	err = json.Unmarshal(bs, &m)
+	return m, err
+}
+
+// Save writes vN.meta.json.
+func (m Meta) Save(path string) error {
+	bs, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	bs = append(bs, '\n')
+	return os.WriteFile(path, bs, 0644)
+}
+
+var rePromptFile = regexp.MustCompile(`^(.+)\.(\d+)\.tmpl$`)
+
+// FindPrompt returns the name, version and path of the template: the given version for "ru.3",
+// or the latest one for "ru".
+func FindPrompt(root, name string) (string, int, string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, PromptsDir))
+	if err != nil {
+		return "", 0, "", err
+	}
+	var found string
+	var version int
+	for _, e := range entries {
+		m := rePromptFile.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		v, _ := strconv.Atoi(m[2])
+		switch {
+		case m[1]+"."+m[2] == name:
+			return m[1], v, filepath.Join(root, PromptsDir, e.Name()), nil
+		case m[1] == name && v > version:
+			found, version = e.Name(), v
+		}
+	}
+	if found == "" {
+		return "", 0, "", fmt.Errorf("no prompt %q in %s", name, PromptsDir)
+	}
+	return name, version, filepath.Join(root, PromptsDir, found), nil
+}
+
+// Prompt is the first message of a chat: the prompt template rendered with vars, and the task of the day.
+// Meta says which prompt it was.
+func Prompt(root, name string, vars PromptVars) (string, Meta, error) {
+	name, version, path, err := FindPrompt(root, name)
+	if err != nil {
+		return "", Meta{}, err
+	}
+	prompt, err := render(path, vars)
+	if err != nil {
+		return "", Meta{}, err
+	}
+	task, err := os.ReadFile(filepath.Join(root, aoc.Dir(vars.Day), "task.txt"))
+	if err != nil {
+		return "", Meta{}, err
+	}
+	return prompt + "\n" + string(task), Meta{Prompt: name, PromptVersion: version, PromptVars: vars}, nil
+}
+
+func render(path string, vars PromptVars) (string, error) {
+	tmpl, err := template.New(filepath.Base(path)).Option("missingkey=error").ParseFiles(path)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	err = tmpl.Execute(&sb, vars)
+	return sb.String(), err
+}
+
+// ChatMeta is the meta of the chat of the attempt: vN.meta.json next to its vN.txt, or the one of v1.
+// Chats from before the templates have none, so it's the template that v1.txt starts with,
+// rendered for Go, 10s, and the model and part of the run. ok is false if none of them is found.
+func ChatMeta(root string, a Attempt) (meta Meta, ok bool, err error) {
+	run := filepath.Join(root, aoc.Dir(a.Day), "o1", filepath.FromSlash(a.Run))
+	v1s := []string{filepath.Join(run, "v1.txt"), filepath.Join(run, "v1", "v1.txt")}
+	own := filepath.Join(root, filepath.FromSlash(path.Dir(a.Path)), "v"+strconv.Itoa(a.Version)+".txt")
+	for _, txt := range append([]string{own}, v1s...) {
+		meta, err := LoadMeta(MetaPath(txt))
+		if err == nil {
+			return meta, true, nil
+		}
+		if !os.IsNotExist(err) {
+			return meta, false, err
+		}
+	}
+	var bs []byte
+	for _, txt := range v1s {
+		if bs, err = os.ReadFile(txt); err == nil {
+			break
+		}
+	}
+	if bs == nil {
+		return Meta{}, false, nil
+	}
+	before, _, _ := strings.Cut(string(bs), "https://")
+	before = strings.TrimSpace(before)
+
+	entries, err := os.ReadDir(filepath.Join(root, PromptsDir))
+	if err != nil {
+		return Meta{}, false, err
+	}
+	vars := PromptVars{Day: a.Day, Part: a.Part(), TimeLimit: 10 * time.Second, Language: "Go", Model: a.Model()}
+	for _, e := range entries {
+		m := rePromptFile.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		prompt, err := render(filepath.Join(root, PromptsDir, e.Name()), vars)
+		if err != nil {
+			return Meta{}, false, err
+		}
+		if strings.TrimSpace(prompt) == before {
+			version, _ := strconv.Atoi(m[2])
+			return Meta{Prompt: m[1], PromptVersion: version, PromptVars: vars}, true, nil
+		}
+	}
+	return Meta{}, false, nil
+}
@@ -0,0 +1,46 @@
+package o1
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// Report of evaluated attempts, as written by cmd/o1eval.
+type Report struct {
+	Time     time.Time     `json:"time"`
+	Go       string        `json:"go"`
+	Timeout  time.Duration `json:"timeout_ns"`
+	Samples  bool          `json:"samples"`
+	Attempts []Result      `json:"attempts"`
+}
+
+// LoadReport reads the report file.
+func LoadReport(path string) (Report, error) {
+	var r Report
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return r, err
+	}
+	err = json.Unmarshal(bs, &r)
+	return r, err
+}
+
+// Save writes the report file.
+func (r Report) Save(path string) error {
+	bs, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	bs = append(bs, '\n')
+	return os.WriteFile(path, bs, 0644)
+}
+
+// Results by attempt path.
+func (r Report) Results() map[string]Result {
+	m := make(map[string]Result, len(r.Attempts))
+	for _, res := range r.Attempts {
+		m[res.Path] = res
+	}
+	return m
+}
@@ -0,0 +1,195 @@
+package o1
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Transcript is vN.txt in parts: the prompt of v1, or what the previous version did,
+// as runs pasted from the shell, with comments of the reviewer around them.
+type Transcript struct {
+	Prompt string  `json:"prompt,omitempty"` // prompt and the task, the whole text of v1
+	Blocks []Block `json:"blocks,omitempty"`
+	// Trailing blank lines at the end.
+	Trailing int `json:"trailing,omitempty"`
+	// NoFinalNewline is set if the last line doesn't end with a newline, and CRLF if every line ends with \r\n.
+	NoFinalNewline bool `json:"no_final_newline,omitempty"`
+	CRLF           bool `json:"crlf,omitempty"`
+}
+
+// BlockKind of a block of the transcript.
+type BlockKind string
+
+const (
+	RunBlock     BlockKind = "run"
+	CommentBlock BlockKind = "comment"
+)
+
+// Block is a run, or a comment between runs.
+type Block struct {
+	Kind BlockKind `json:"kind"`
+	// Spacing is blank lines before the block, beyond the usual one, or -1 if there are none.
+	Spacing int    `json:"spacing,omitempty"`
+	Text    string `json:"text,omitempty"` // of the comment
+
+	Command       string         `json:"command,omitempty"` // after ➜, like "go run ./o1/v1 sample.txt"
+	Input         string         `json:"input,omitempty"`   // file the command was run on
+	Output        string         `json:"output,omitempty"`
+	Answers       map[int]string `json:"answers,omitempty"`        // found in the output, by part
+	CompileErrors []string       `json:"compile_errors,omitempty"` // like "o1/v1/v1.go:349:2: declared and not used: rows"
+}
+
+var (
+	reTaskURL      = regexp.MustCompile(`(?m)^https://adventofcode\.com/\d+/day/\d+$`)
+	reCompileError = regexp.MustCompile(`^\S+\.go:\d+:\d+: `)
+	reNumber       = regexp.MustCompile(`-?\d+`)
+)
+
+// ParseTranscript splits the text of vN.txt. A run is "➜ command" and the output right after it,
+// up to a blank line, a code fence, or the next command. The rest is comments.
+func ParseTranscript(text string) Transcript {
+	var t Transcript
+	if n := strings.Count(text, "\n"); n > 0 && strings.Count(text, "\r\n") == n {
+		t.CRLF = true
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+	}
+	if text == "" {
+		return t
+	}
+	t.NoFinalNewline = !strings.HasSuffix(text, "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if reTaskURL.MatchString(text) {
+		t.Prompt = text
+		return t
+	}
+
+	lines := strings.Split(text, "\n")
+	blank := 0 // blank lines before the next block
+	var comment []string
+	add := func(b Block) {
+		if len(t.Blocks) > 0 {
+			b.Spacing = blank - 1
+		} else {
+			b.Spacing = blank
+		}
+		t.Blocks = append(t.Blocks, b)
+		blank = 0
+	}
+	flush := func() {
+		// blank lines at the end of the comment are before the next block
+		n := len(comment)
+		for n > 0 && comment[n-1] == "" {
+			n--
+		}
+		if n > 0 {
+			add(Block{Kind: CommentBlock, Text: strings.Join(comment[:n], "\n")})
+			blank = len(comment) - n
+		}
+		comment = nil
+	}
+	for i := 0; i < len(lines); i++ {
+		command, ok := strings.CutPrefix(lines[i], "➜ ")
+		if !ok || strings.TrimSpace(command) == "" {
+			if len(comment) == 0 && lines[i] == "" {
+				blank++
+				continue
+			}
+			comment = append(comment, lines[i])
+			continue
+		}
+		flush()
+		j := i + 1
+		for j < len(lines) && lines[j] != "" && lines[j] != "```" && !strings.HasPrefix(lines[j], "➜") {
+			j++
+		}
+		add(newRun(command, lines[i+1:j]))
+		i = j - 1
+	}
+	flush()
+	t.Trailing = blank
+	return t
+}
+
+func newRun(command string, output []string) Block {
+	b := Block{Kind: RunBlock, Command: command, Output: strings.Join(output, "\n")}
+	if fields := strings.Fields(command); len(fields) > 0 && strings.HasSuffix(fields[len(fields)-1], ".txt") {
+		b.Input = fields[len(fields)-1]
+	}
+	for _, line := range output {
+		if reCompileError.MatchString(line) {
+			b.CompileErrors = append(b.CompileErrors, line)
+		}
+	}
+	if b.CompileErrors == nil {
+		b.Answers = FindAnswers(b.Output)
+	}
+	return b
+}
+
+// FindAnswers is what the output gives as answers: the first number in lines mentioning the part,
+// or numbers on their own lines, in order of parts, if no line mentions them. Printed times are skipped.
+func FindAnswers(output string) map[int]string {
+	lines := strings.Split(reDuration.ReplaceAllString(output, ""), "\n")
+	answers := map[int]string{}
+	for _, part := range []int{1, 2} {
+		for _, line := range lines {
+			if rest := rePart[part].ReplaceAllString(line, ""); rest != line {
+				if n := reNumber.FindString(rest); n != "" {
+					answers[part] = n
+					break
+				}
+			}
+		}
+	}
+	if len(answers) > 0 {
+		return answers
+	}
+	for _, line := range lines {
+		if _, err := strconv.Atoi(strings.TrimSpace(line)); err == nil && len(answers) < 2 {
+			answers[len(answers)+1] = strings.TrimSpace(line)
+		}
+	}
+	if len(answers) == 0 {
+		return nil
+	}
+	return answers
+}
+
+// String is the text of vN.txt, as it was parsed.
+func (t Transcript) String() string {
+	text := t.text()
+	if t.NoFinalNewline {
+		text = strings.TrimSuffix(text, "\n")
+	}
+	if t.CRLF {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	return text
+}
+
+// text of the transcript, with \n line endings.
+func (t Transcript) text() string {
+	if t.Prompt != "" {
+		return t.Prompt + "\n"
+	}
+	var sb strings.Builder
+	for i, b := range t.Blocks {
+		blank := b.Spacing
+		if i > 0 {
+			blank++
+		}
+		sb.WriteString(strings.Repeat("\n", max(blank, 0)))
+		switch b.Kind {
+		case RunBlock:
+			sb.WriteString("➜ " + b.Command + "\n")
+			if b.Output != "" {
+				sb.WriteString(b.Output + "\n")
+			}
+		default:
+			sb.WriteString(b.Text + "\n")
+		}
+	}
+	sb.WriteString(strings.Repeat("\n", t.Trailing))
+	return sb.String()
+}
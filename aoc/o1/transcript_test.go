@@ -0,0 +1,82 @@
+package o1
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTranscript(t *testing.T) {
+	run := func(command, output string, answers map[int]string) Block {
+		b := Block{Kind: RunBlock, Command: command, Output: output, Answers: answers}
+		if fields := strings.Fields(command); strings.HasSuffix(fields[len(fields)-1], ".txt") {
+			b.Input = fields[len(fields)-1]
+		}
+		return b
+	}
+	comment := func(spacing int, text string) Block {
+		return Block{Kind: CommentBlock, Spacing: spacing, Text: text}
+	}
+	for _, tt := range []struct {
+		name string
+		text string
+		want Transcript
+	}{
+		{"empty", "", Transcript{}},
+		{"blank line", "\n", Transcript{Trailing: 1}},
+		{"prompt", "Реши задачу.\n\nhttps://adventofcode.com/2024/day/1\n\n--- Day 1 ---\n",
+			Transcript{Prompt: "Реши задачу.\n\nhttps://adventofcode.com/2024/day/1\n\n--- Day 1 ---"}},
+		{"prompt without final newline", "Реши задачу.\nhttps://adventofcode.com/2024/day/1",
+			Transcript{Prompt: "Реши задачу.\nhttps://adventofcode.com/2024/day/1", NoFinalNewline: true}},
+		{"run", "➜ go run ./o1/v1 input.txt\nPart 1: 42\nPart 2: 7\n",
+			Transcript{Blocks: []Block{run("go run ./o1/v1 input.txt", "Part 1: 42\nPart 2: 7", map[int]string{1: "42", 2: "7"})}}},
+		{"run at EOF", "Неверно.\n\n➜ go run ./o1/v1 input.txt\n42",
+			Transcript{Blocks: []Block{comment(0, "Неверно."), run("go run ./o1/v1 input.txt", "42", map[int]string{1: "42"})}, NoFinalNewline: true}},
+		{"command at EOF", "➜ go run ./o1/v1",
+			Transcript{Blocks: []Block{run("go run ./o1/v1", "", nil)}, NoFinalNewline: true}},
+		{"compile errors", "➜ go run ./o1/v1\no1/v1/v1.go:3:2: declared and not used: x\n\n\nИсправь.\n\n",
+			Transcript{Blocks: []Block{
+				{Kind: RunBlock, Command: "go run ./o1/v1", Output: "o1/v1/v1.go:3:2: declared and not used: x",
+					CompileErrors: []string{"o1/v1/v1.go:3:2: declared and not used: x"}},
+				comment(1, "Исправь."),
+			}, Trailing: 1}},
+		{"crlf", "➜ go run ./o1/v1 sample.txt\r\n1\r\n\r\nВерно.\r\n",
+			Transcript{Blocks: []Block{run("go run ./o1/v1 sample.txt", "1", map[int]string{1: "1"}), comment(0, "Верно.")}, CRLF: true}},
+		{"crlf without final newline", "Верно.\r\nНо медленно.",
+			Transcript{Blocks: []Block{comment(0, "Верно.\nНо медленно.")}, NoFinalNewline: true, CRLF: true}},
+		{"mixed line endings", "Верно.\r\nНо медленно.\n",
+			Transcript{Blocks: []Block{comment(0, "Верно.\r\nНо медленно.")}}},
+		{"leading blank lines", "\n\nВерно.\n",
+			Transcript{Blocks: []Block{comment(2, "Верно.")}}},
+	} {
+		got := ParseTranscript(tt.text)
+		assert.Equal(t, tt.want, got, tt.name)
+		assert.Equal(t, tt.text, got.String(), "%s: round trip", tt.name)
+	}
+}
+
+var reTxt = regexp.MustCompile(`^v\d+\.txt$`)
+
+// every vN.txt of the repo makes the same text back
+func TestRoundTrip(t *testing.T) {
+	var n int
+	err := filepath.WalkDir("../..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !reTxt.MatchString(d.Name()) || !slices.Contains(strings.Split(filepath.ToSlash(path), "/"), "o1") {
+			return err
+		}
+		bs, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, string(bs), ParseTranscript(string(bs)).String(), path)
+		n++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Positive(t, n)
+}
@@ -0,0 +1,272 @@
+// Package parse splits inputs into the usual AoC shapes: sections, lines, int lists,
+// key: value pairs, grids, and structs matched by regexp.
+//
+// Every piece of input is a Text, which knows its line and column in the whole input,
+// so errors point to the place, like "input.txt:12:7: bad int "x"".
+// File name is added by runners, see WithFile.
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+)
+
+// Error is a parse error at a position of the input file.
+type Error struct {
+	File      string // empty if unknown
+	Line, Col int    // 1-based
+	Err       error
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	if e.File != "" {
+		sb.WriteString(e.File)
+		sb.WriteByte(':')
+	}
+	fmt.Fprintf(&sb, "%d:%d: %v", e.Line, e.Col, e.Err)
+	return sb.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithFile sets the file name of parse error in err, if there is one.
+// Messages of wrapping errors are already made, so they are patched too.
+func WithFile(err error, file string) error {
+	var pe *Error
+	if file == "" || !errors.As(err, &pe) || pe.File != "" {
+		return err
+	}
+	old := pe.Error()
+	pe.File = file
+	if err == error(pe) {
+		return err
+	}
+	return &wrapped{msg: strings.Replace(err.Error(), old, pe.Error(), 1), err: err}
+}
+
+type wrapped struct {
+	msg string
+	err error
+}
+
+func (w *wrapped) Error() string { return w.msg }
+func (w *wrapped) Unwrap() error { return w.err }
+
+// Text is a part of the input, with position of its start.
+type Text struct {
+	S         string
+	Line, Col int // 1-based
+}
+
+// Input is the whole input.
+func Input(s string) Text {
+	return Text{S: s, Line: 1, Col: 1}
+}
+
+func (t Text) String() string {
+	return t.S
+}
+
+// pos returns line and column of byte offset in the text.
+func (t Text) pos(offset int) (line, col int) {
+	before := t.S[:offset]
+	n := strings.Count(before, "\n")
+	if n == 0 {
+		return t.Line, t.Col + offset
+	}
+	return t.Line + n, offset - strings.LastIndexByte(before, '\n')
+}
+
+// Slice is t.S[from:to], with its position.
+func (t Text) Slice(from, to int) Text {
+	line, col := t.pos(from)
+	return Text{S: t.S[from:to], Line: line, Col: col}
+}
+
+// Errorf makes an error at the start of the text.
+func (t Text) Errorf(format string, a ...any) error {
+	return t.ErrorAt(0, format, a...)
+}
+
+// ErrorAt makes an error at byte offset in the text.
+func (t Text) ErrorAt(offset int, format string, a ...any) error {
+	line, col := t.pos(offset)
+	return &Error{Line: line, Col: col, Err: fmt.Errorf(format, a...)}
+}
+
+// spans returns [start, end) offsets of lines. Trailing newline doesn't make an empty line.
+func (t Text) spans() [][2]int {
+	var spans [][2]int
+	for start := 0; start < len(t.S); {
+		end := strings.IndexByte(t.S[start:], '\n')
+		if end == -1 {
+			end = len(t.S)
+		} else {
+			end += start
+		}
+		spans = append(spans, [2]int{start, end})
+		start = end + 1
+	}
+	return spans
+}
+
+// Lines splits the text into lines. Trailing newline doesn't make an empty line.
+func (t Text) Lines() []Text {
+	spans := t.spans()
+	lines := make([]Text, len(spans))
+	for i, span := range spans {
+		lines[i] = t.line(i, span)
+	}
+	return lines
+}
+
+// line i of the text, without counting newlines before it again.
+func (t Text) line(i int, span [2]int) Text {
+	line := Text{S: t.S[span[0]:span[1]], Line: t.Line + i, Col: 1}
+	if i == 0 {
+		line.Col = t.Col
+	}
+	return line
+}
+
+// Sections splits the text by blank lines. Each section has no trailing newline.
+func (t Text) Sections() []Text {
+	var sections []Text
+	var section Text
+	start := -1
+	for i, span := range t.spans() {
+		if strings.TrimSpace(t.S[span[0]:span[1]]) == "" {
+			if start >= 0 {
+				sections = append(sections, section)
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = span[0]
+			section = t.line(i, span)
+		}
+		section.S = t.S[start:span[1]]
+	}
+	if start >= 0 {
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// SectionsN is Sections, which must be exactly n.
+func (t Text) SectionsN(n int) ([]Text, error) {
+	sections := t.Sections()
+	if len(sections) != n {
+		return nil, t.Errorf("expected %d sections separated by blank lines, got %d", n, len(sections))
+	}
+	return sections, nil
+}
+
+// Trim removes spaces and newlines around the text.
+func (t Text) Trim() Text {
+	s := strings.TrimLeft(t.S, " \t\r\n")
+	start := len(t.S) - len(s)
+	return t.Slice(start, start+len(strings.TrimRight(s, " \t\r\n")))
+}
+
+// Int parses the whole text as an int, ignoring spaces around.
+func (t Text) Int() (int, error) {
+	t = t.Trim()
+	n, err := strconv.Atoi(t.S)
+	if err != nil {
+		return 0, t.Errorf("bad int %q", t.S)
+	}
+	return n, nil
+}
+
+// IntSeparators are allowed between ints of a list.
+const IntSeparators = " \t,|:;"
+
+// Ints parses a list of ints separated by spaces, commas, or other IntSeparators, like "1 2 3", "75,47,61", "47|53" or "190: 10 19".
+// Anything else is an error.
+func (t Text) Ints() ([]int, error) {
+	var ints []int
+	for _, f := range t.Fields(IntSeparators) {
+		n, err := f.Int()
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, n)
+	}
+	return ints, nil
+}
+
+// Fields splits the text by runs of separator chars.
+func (t Text) Fields(separators string) []Text {
+	var fields []Text
+	start := -1
+	for i, c := range t.S {
+		if strings.ContainsRune(separators, c) || c == '\n' {
+			if start >= 0 {
+				fields = append(fields, t.Slice(start, i))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, t.Slice(start, len(t.S)))
+	}
+	return fields
+}
+
+// Split splits the text by separator, like strings.Split.
+func (t Text) Split(sep string) []Text {
+	var parts []Text
+	start := 0
+	for {
+		i := strings.Index(t.S[start:], sep)
+		if i == -1 {
+			return append(parts, t.Slice(start, len(t.S)))
+		}
+		parts = append(parts, t.Slice(start, start+i))
+		start += i + len(sep)
+	}
+}
+
+// KeyValue splits "key: value" at the first sep. Spaces around key and value are trimmed.
+func (t Text) KeyValue(sep string) (key, value Text, err error) {
+	i := strings.Index(t.S, sep)
+	if i == -1 {
+		return Text{}, Text{}, t.Errorf("expected %q in %q", sep, t.S)
+	}
+	key = t.Slice(0, i).Trim()
+	value = t.Slice(i+len(sep), len(t.S)).Trim()
+	if key.S == "" {
+		return Text{}, Text{}, t.Errorf("empty key in %q", t.S)
+	}
+	return key, value, nil
+}
+
+// Grid parses the text as a rune grid, with all lines of the same length.
+func (t Text) Grid() (grid.Grid[rune], error) {
+	lines := t.Lines()
+	if len(lines) == 0 {
+		return grid.Grid[rune]{}, t.Errorf("empty grid")
+	}
+	ss := make([]string, len(lines))
+	w := len([]rune(lines[0].S))
+	for i, line := range lines {
+		if n := len([]rune(line.S)); n != w {
+			return grid.Grid[rune]{}, line.Errorf("line length %d, expected %d", n, w)
+		}
+		ss[i] = line.S
+	}
+	return grid.FromLines(ss)
+}
@@ -0,0 +1,157 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositions(t *testing.T) {
+	in := Input("1 2 3\n4 x 6\n\na: 7\nb 8\n\n..#\n.#\n")
+	sections := in.Sections()
+	require.Len(t, sections, 3)
+	for _, tt := range []struct {
+		name string
+		err  func() error
+		want string
+	}{
+		{"ints", func() error { _, err := sections[0].Ints(); return err }, `2:3: bad int "x"`},
+		{"int of a line", func() error { _, err := sections[0].Lines()[1].Fields(" ")[1].Int(); return err }, `2:3: bad int "x"`},
+		{"trimmed int", func() error { _, err := in.Slice(5, 9).Int(); return err }, `2:1: bad int "4 x"`},
+		{"key value", func() error { _, _, err := sections[1].Lines()[1].KeyValue(":"); return err }, `5:1: expected ":" in "b 8"`},
+		{"empty key", func() error { _, _, err := Input("x\n : 1").Lines()[1].KeyValue(":"); return err }, `2:1: empty key in " : 1"`},
+		{"value", func() error {
+			_, v, err := sections[1].Lines()[0].KeyValue(":")
+			require.NoError(t, err)
+			return v.Slice(1, 1).Errorf("after %q", v)
+		}, `4:5: after "7"`},
+		{"grid", func() error { _, err := sections[2].Grid(); return err }, "8:1: line length 2, expected 3"},
+		{"empty grid", func() error { _, err := Input("").Grid(); return err }, "1:1: empty grid"},
+		{"sections", func() error { _, err := in.SectionsN(2); return err }, "1:1: expected 2 sections separated by blank lines, got 3"},
+		{"error at", func() error { return in.Lines()[1].ErrorAt(4, "here") }, "2:5: here"},
+		{"split", func() error { return Input("ab, cd,\nef").Split(", ")[1].ErrorAt(4, "here") }, "2:1: here"},
+	} {
+		err := tt.err()
+		var pe *Error
+		require.ErrorAs(t, err, &pe, tt.name)
+		assert.EqualError(t, err, tt.want, tt.name)
+	}
+}
+
+func TestLines(t *testing.T) {
+	for _, tt := range []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"a", []string{"a"}},
+		{"a\n", []string{"a"}},
+		{"a\n\nb", []string{"a", "", "b"}},
+		{"a\nb\n\n", []string{"a", "b", ""}},
+	} {
+		var got []string
+		for i, line := range Input(tt.input).Lines() {
+			got = append(got, line.S)
+			assert.Equal(t, i+1, line.Line, "%q", tt.input)
+			assert.Equal(t, 1, line.Col, "%q", tt.input)
+		}
+		assert.Equal(t, tt.want, got, "%q", tt.input)
+	}
+}
+
+// Dir is a TextUnmarshaler, to be scanned as one.
+type Dir byte
+
+func (d *Dir) UnmarshalText(b []byte) error {
+	if len(b) != 1 || !strings.Contains("^>v<", string(b)) {
+		return fmt.Errorf("bad dir %q", b)
+	}
+	*d = Dir(b[0])
+	return nil
+}
+
+type Move struct {
+	Name  string `re:"name"`
+	Pos   Text   `re:"name"`
+	N     int    `re:"n"`
+	Small uint8  `re:"n"`
+	Dir   Dir    `re:"dir"`
+	Fast  bool   `re:"fast"`
+	Path  []int  `re:"path"`
+	Note  string // not scanned
+}
+
+var reMove = regexp.MustCompile(`(?P<name>\w+) (?P<n>-?\d+)(?P<dir>.)(?: fast=(?P<fast>\w+))?(?: via (?P<path>[\d,]+))?`)
+
+func TestScan(t *testing.T) {
+	for _, tt := range []struct {
+		line string
+		want Move
+		err  string
+	}{
+		{"robot 12> fast=true via 1,2,3", Move{Name: "robot", N: 12, Small: 12, Dir: '>', Fast: true, Path: []int{1, 2, 3}}, ""},
+		{"robot 7^", Move{Name: "robot", N: 7, Small: 7, Dir: '^'}, ""},
+		{"robot -1v", Move{}, `3:7: bad uint "-1"`},
+		{"robot 300v", Move{}, `3:7: bad uint "300"`},
+		{"robot 1x", Move{}, `3:8: bad dir "x"`},
+		{"robot 1> fast=maybe", Move{}, `3:15: bad bool "maybe"`},
+		{"robot 1> and more", Move{}, `3:9: unexpected " and more" after ` + reMove.String()},
+		{"+ 1>", Move{}, `3:1: "+ 1>" doesn't match ` + reMove.String()},
+	} {
+		line := Input("\n\n" + tt.line).Lines()[2]
+		got, err := Scan[Move](line, reMove)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, tt.line)
+			continue
+		}
+		require.NoError(t, err, tt.line)
+		assert.Equal(t, Text{S: "robot", Line: 3, Col: 1}, got.Pos, tt.line)
+		got.Pos = Text{}
+		assert.Equal(t, tt.want, got, tt.line)
+	}
+
+	moves, err := ScanLines[Move](Input("a 1>\nb 2<\n"), reMove)
+	require.NoError(t, err)
+	require.Len(t, moves, 2)
+	assert.Equal(t, 2, moves[1].Pos.Line)
+
+	_, err = Scan[Move](Input("a 1>"), regexp.MustCompile(`(?P<name>\w+) (?P<n>\d+)>`))
+	assert.EqualError(t, err, `parse.Scan: field Dir: no group "dir" in (?P<name>\w+) (?P<n>\d+)>`)
+	_, err = Scan[int](Input("1"), regexp.MustCompile(`\d`))
+	assert.EqualError(t, err, "parse.Scan: int is not a struct")
+}
+
+func TestWithFile(t *testing.T) {
+	posErr := func() error { return Input("a\nb").Lines()[1].Errorf("nope") }
+	other := errors.New("other")
+	for _, tt := range []struct {
+		name string
+		err  error
+		file string
+		want string
+	}{
+		{"parse error", posErr(), "input.txt", "input.txt:2:1: nope"},
+		{"wrapped", fmt.Errorf("day 5: %w", posErr()), "input.txt", "day 5: input.txt:2:1: nope"},
+		{"wrapped twice", fmt.Errorf("parse: %w", fmt.Errorf("day 5: %w", posErr())), "a.txt", "parse: day 5: a.txt:2:1: nope"},
+		{"no file", posErr(), "", "2:1: nope"},
+		{"file already", WithFile(posErr(), "sample.txt"), "input.txt", "sample.txt:2:1: nope"},
+		{"other error", other, "input.txt", "other"},
+		{"nil", nil, "input.txt", ""},
+	} {
+		err := WithFile(tt.err, tt.file)
+		if tt.err == nil {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+		assert.EqualError(t, err, tt.want, tt.name)
+		assert.ErrorIs(t, err, tt.err, "%s: still unwraps", tt.name)
+	}
+	var pe *Error
+	require.ErrorAs(t, WithFile(fmt.Errorf("day 5: %w", posErr()), "input.txt"), &pe)
+	assert.Equal(t, Error{File: "input.txt", Line: 2, Col: 1, Err: pe.Err}, *pe)
+}
@@ -0,0 +1,123 @@
+package parse
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+// Scan matches the whole text with re, and sets fields of struct T tagged `re:"name"` from named groups of re.
+// Fields can be string, Text, bool, ints, uints, []int (parsed with Ints), or encoding.TextUnmarshaler.
+// Fields of groups which didn't participate in the match are left zero.
+//
+//	type Wire struct {
+//		Name  string `re:"name"`
+//		Value int    `re:"value"`
+//	}
+//	wire, err := parse.Scan[Wire](line, regexp.MustCompile(`(?P<name>\w+): (?P<value>[01])`))
+func Scan[T any](t Text, re *regexp.Regexp) (T, error) {
+	var v T
+	err := scan(t, re, reflect.ValueOf(&v).Elem())
+	return v, err
+}
+
+// ScanEach scans each text, like sections.
+func ScanEach[T any](ts []Text, re *regexp.Regexp) ([]T, error) {
+	vs := make([]T, len(ts))
+	for i, t := range ts {
+		var err error
+		if vs[i], err = Scan[T](t, re); err != nil {
+			return nil, err
+		}
+	}
+	return vs, nil
+}
+
+// ScanLines scans each line of the text.
+func ScanLines[T any](t Text, re *regexp.Regexp) ([]T, error) {
+	return ScanEach[T](t.Lines(), re)
+}
+
+var textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+func scan(t Text, re *regexp.Regexp, v reflect.Value) error {
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("parse.Scan: %v is not a struct", v.Type())
+	}
+	loc := re.FindStringSubmatchIndex(t.S)
+	if loc == nil || loc[0] != 0 {
+		return t.Errorf("%q doesn't match %v", t.S, re)
+	}
+	if loc[1] != len(t.S) {
+		return t.ErrorAt(loc[1], "unexpected %q after %v", t.S[loc[1]:], re)
+	}
+	typ := v.Type()
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		name, ok := f.Tag.Lookup("re")
+		if !ok {
+			continue
+		}
+		group := re.SubexpIndex(name)
+		if group < 0 {
+			return fmt.Errorf("parse.Scan: field %s: no group %q in %v", f.Name, name, re)
+		}
+		start, end := loc[2*group], loc[2*group+1]
+		if start < 0 {
+			continue
+		}
+		if err := set(v.Field(i), t.Slice(start, end)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func set(field reflect.Value, t Text) error {
+	if field.Addr().Type().Implements(textUnmarshaler) {
+		if err := field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(t.S)); err != nil {
+			return t.Errorf("%v", err)
+		}
+		return nil
+	}
+	if field.Type() == reflect.TypeFor[Text]() {
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(t.S)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(t.S)
+		if err != nil {
+			return t.Errorf("bad bool %q", t.S)
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(t.S, 10, field.Type().Bits())
+		if err != nil {
+			return t.Errorf("bad int %q", t.S)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(t.S, 10, field.Type().Bits())
+		if err != nil {
+			return t.Errorf("bad uint %q", t.S)
+		}
+		field.SetUint(n)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.Int {
+			return fmt.Errorf("parse.Scan: unsupported field type %v", field.Type())
+		}
+		ints, err := t.Ints()
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(ints).Convert(field.Type()))
+	default:
+		return fmt.Errorf("parse.Scan: unsupported field type %v", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Solver solves one day. P is the parsed input, shared by both parts.
+// Parts must not modify P, so they can be run in any order or alone.
+// Long parts should check ctx and return ctx.Err() when it's done.
+type Solver[P any] interface {
+	Parse(input string) (P, error)
+	Part1(ctx context.Context, parsed P) (Answer, error)
+	Part2(ctx context.Context, parsed P) (Answer, error)
+}
+
+// Parts is implemented by solvers of days without part 2, like day 25.
+type Parts interface {
+	Parts() int
+}
+
+// Puzzle is a registered Solver with the parsed type erased,
+// so days with different inputs fit in one registry.
+type Puzzle struct {
+	Day   int
+	parse func(input string) (any, error)
+	parts []func(ctx context.Context, parsed any) (Answer, error)
+	bg    *background
+}
+
+// background counts parts started by Solve that haven't returned yet, including those it stopped waiting for.
+type background struct {
+	wg sync.WaitGroup
+	n  atomic.Int32
+}
+
+var puzzles = map[int]Puzzle{}
+
+// Register adds the solver of the day to the registry. It's meant to be called from init.
+func Register[P any](day int, s Solver[P]) {
+	if _, ok := puzzles[day]; ok {
+		panic(fmt.Sprintf("day %d registered twice", day))
+	}
+	puzzle := Puzzle{
+		Day: day,
+		bg:  &background{},
+		parse: func(input string) (any, error) {
+			return s.Parse(input)
+		},
+		parts: []func(context.Context, any) (Answer, error){
+			func(ctx context.Context, parsed any) (Answer, error) { return s.Part1(ctx, parsed.(P)) },
+			func(ctx context.Context, parsed any) (Answer, error) { return s.Part2(ctx, parsed.(P)) },
+		},
+	}
+	if p, ok := s.(Parts); ok {
+		puzzle.parts = puzzle.parts[:p.Parts()]
+	}
+	puzzles[day] = puzzle
+}
+
+// Get returns the puzzle of the day.
+func Get(day int) (Puzzle, bool) {
+	p, ok := puzzles[day]
+	return p, ok
+}
+
+// Days returns registered days in order.
+func Days() []int {
+	days := make([]int, 0, len(puzzles))
+	for day := range puzzles {
+		days = append(days, day)
+	}
+	slices.Sort(days)
+	return days
+}
+
+// PanicError is a panic of a solver, recovered and returned as an error.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = &PanicError{Value: r, Stack: debug.Stack()}
+	}
+}
+
+// Parts lists parts of the puzzle.
+func (p Puzzle) Parts() []int {
+	parts := make([]int, len(p.parts))
+	for i := range parts {
+		parts[i] = i + 1
+	}
+	return parts
+}
+
+// Parse parses the input.
+func (p Puzzle) Parse(input string) (parsed any, err error) {
+	defer recoverPanic(&err)
+	parsed, err = p.parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("day %d: parse: %w", p.Day, err)
+	}
+	return parsed, nil
+}
+
+// Solve runs the part (1 or 2) on the parsed input.
+// If ctx is done first, Solve returns its error right away, even if the part doesn't check ctx,
+// in which case the part is left running in background until it returns. See Running and Wait.
+func (p Puzzle) Solve(ctx context.Context, parsed any, part int) (answer Answer, err error) {
+	if part < 1 || part > len(p.parts) {
+		return nil, fmt.Errorf("day %d part %d: %w", p.Day, part, ErrNoPart)
+	}
+	if ctx.Done() == nil {
+		// can't be cancelled, like in benchmarks
+		return p.solve(ctx, parsed, part)
+	}
+	type result struct {
+		answer Answer
+		err    error
+	}
+	done := make(chan result, 1)
+	p.bg.wg.Add(1)
+	p.bg.n.Add(1)
+	go func() {
+		defer p.bg.wg.Done()
+		defer p.bg.n.Add(-1)
+		answer, err := p.solve(ctx, parsed, part)
+		done <- result{answer, err}
+	}()
+	select {
+	case r := <-done:
+		return r.answer, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Running reports if a part of the day started by Solve hasn't returned yet, like one left running after a timeout.
+// Solvers keep settings in package variables, so another run of the day shouldn't start until it returns.
+func (p Puzzle) Running() bool {
+	return p.bg.n.Load() > 0
+}
+
+// Wait waits for parts of the day started by Solve to return.
+func (p Puzzle) Wait() {
+	p.bg.wg.Wait()
+}
+
+// SolveTimeout is Solve with a time limit, 0 for none. Running out of time is "timeout after X", see ErrTimeout.
+func (p Puzzle) SolveTimeout(ctx context.Context, parsed any, part int, timeout time.Duration) (Answer, error) {
+	if timeout <= 0 {
+		return p.Solve(ctx, parsed, part)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	answer, err := p.Solve(ctx, parsed, part)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w after %v", ErrTimeout, timeout)
+	}
+	return answer, err
+}
+
+func (p Puzzle) solve(ctx context.Context, parsed any, part int) (answer Answer, err error) {
+	defer recoverPanic(&err)
+	return p.parts[part-1](ctx, parsed)
+}
@@ -0,0 +1,167 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/parse"
+)
+
+// Result is the outcome of one part on one input.
+type Result struct {
+	Day    int    `json:"day"`
+	Part   int    `json:"part"`
+	Input  string `json:"input"`
+	Answer string `json:"answer,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Timeout is set if the part ran out of time. Error says how long it was given.
+	Timeout bool `json:"timeout,omitempty"`
+	// Panic is set if the part panicked, and Unsupported if it refused the input with ErrUnsupported.
+	Panic       bool          `json:"panic,omitempty"`
+	Unsupported bool          `json:"unsupported,omitempty"`
+	Time        time.Duration `json:"time_ns"`
+}
+
+// Dir is the folder of the day, relative to the repository root.
+func Dir(day int) string {
+	return fmt.Sprintf("%02d", day)
+}
+
+// RunFile reads the input file from the day folder under root and runs the parts on it.
+// Each part has up to timeout to finish, 0 for no limit.
+func (p Puzzle) RunFile(ctx context.Context, root, input string, timeout time.Duration, parts ...int) []Result {
+	bs, err := os.ReadFile(filepath.Join(root, Dir(p.Day), input))
+	if err != nil {
+		return failAll(p.Day, input, parts, err)
+	}
+	return p.run(ctx, input, string(bs), timeout, parts)
+}
+
+// Run parses the input once and runs the parts on it, one after another.
+// Each part has up to timeout to finish, 0 for no limit.
+func (p Puzzle) Run(ctx context.Context, input string, timeout time.Duration, parts ...int) []Result {
+	return p.run(ctx, "", input, timeout, parts)
+}
+
+// run is Run of the named input file, to have the name in results and parse errors.
+func (p Puzzle) run(ctx context.Context, name, input string, timeout time.Duration, parts []int) []Result {
+	parsed, err := p.Parse(input)
+	if err != nil {
+		return failAll(p.Day, name, parts, parse.WithFile(err, name))
+	}
+	if name != "" {
+		ctx = WithInput(ctx, name)
+	}
+	results := make([]Result, 0, len(parts))
+	for _, part := range parts {
+		res := Result{Day: p.Day, Part: part, Input: name}
+		timeStart := time.Now()
+		answer, err := p.SolveTimeout(ctx, parsed, part, timeout)
+		res.Time = time.Since(timeStart)
+		if errors.Is(err, ErrNoPart) {
+			continue
+		}
+		res.Timeout = errors.Is(err, ErrTimeout)
+		res.Unsupported = errors.Is(err, ErrUnsupported)
+		var pe *PanicError
+		res.Panic = errors.As(err, &pe)
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Answer = answer.String()
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+func failAll(day int, input string, parts []int, err error) []Result {
+	var pe *PanicError
+	results := make([]Result, len(parts))
+	for i, part := range parts {
+		results[i] = Result{Day: day, Part: part, Input: input, Error: err.Error(), Panic: errors.As(err, &pe)}
+	}
+	return results
+}
+
+// ParseDays parses day list like "5-12,14,20-". Empty string or "all" selects all registered days.
+// A range without a start or an end goes from the first or to the last registered day.
+func ParseDays(s string) ([]int, error) {
+	all := Days()
+	if s == "" || s == "all" {
+		return all, nil
+	}
+	if len(all) == 0 {
+		return nil, errors.New("no days registered")
+	}
+	var days []int
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		from, to, isRange := strings.Cut(item, "-")
+		if item == "" || item == "-" {
+			return nil, fmt.Errorf("empty day in %q", s)
+		}
+		first, err := parseDay(from, all[0])
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			last, err = parseDay(to, all[len(all)-1])
+			if err != nil {
+				return nil, err
+			}
+			if first > last {
+				return nil, fmt.Errorf("reversed range %q", item)
+			}
+		}
+		if !isRange {
+			if _, ok := Get(first); !ok {
+				return nil, fmt.Errorf("day %d is not registered", first)
+			}
+		}
+		found := false
+		for _, day := range all {
+			if first <= day && day <= last {
+				found = true
+				if !slices.Contains(days, day) {
+					days = append(days, day)
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no registered days in %q", item)
+		}
+	}
+	slices.Sort(days)
+	return days, nil
+}
+
+func parseDay(s string, def int) (int, error) {
+	if s == "" {
+		return def, nil
+	}
+	day, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("bad day %q", s)
+	}
+	return day, nil
+}
+
+// ParseParts parses part selector: 1, 2, or 0 for both.
+func ParseParts(part int) ([]int, error) {
+	switch part {
+	case 0:
+		return []int{1, 2}, nil
+	case 1, 2:
+		return []int{part}, nil
+	}
+	return nil, fmt.Errorf("bad part %d", part)
+}
@@ -0,0 +1,82 @@
+package aoc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	_ "github.com/metalim/adventofcode.2024.go/aoc/all"
+)
+
+// days from-to, inclusive.
+func days(from, to int) []int {
+	var d []int
+	for day := from; day <= to; day++ {
+		d = append(d, day)
+	}
+	return d
+}
+
+func TestParseDays(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want []int
+		err  string
+	}{
+		{"", days(1, 25), ""},
+		{"all", days(1, 25), ""},
+		{"5", []int{5}, ""},
+		{"5-12,14,20-", append(append(days(5, 12), 14), days(20, 25)...), ""},
+		{"-3", days(1, 3), ""},
+		{" 7 , 3 ", []int{3, 7}, ""},
+		{"3,1-4,3", days(1, 4), ""},
+		{"5-5", []int{5}, ""},
+		{"20-30", days(20, 25), ""},
+		{"5,", nil, `empty day in "5,"`},
+		{",5", nil, `empty day in ",5"`},
+		{"5,,6", nil, `empty day in "5,,6"`},
+		{" ", nil, `empty day in " "`},
+		{"-", nil, `empty day in "-"`},
+		{"12-5", nil, `reversed range "12-5"`},
+		{"x", nil, `bad day "x"`},
+		{"1-x", nil, `bad day "x"`},
+		{"1-2-3", nil, `bad day "2-3"`},
+		{"0", nil, "day 0 is not registered"},
+		{"26", nil, "day 26 is not registered"},
+		{"30-40", nil, `no registered days in "30-40"`},
+	} {
+		got, err := aoc.ParseDays(tt.s)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "%q", tt.s)
+			continue
+		}
+		require.NoError(t, err, "%q", tt.s)
+		assert.Equal(t, tt.want, got, "%q", tt.s)
+		assert.True(t, slices.IsSorted(got), "%q", tt.s)
+	}
+}
+
+func TestParseParts(t *testing.T) {
+	for _, tt := range []struct {
+		part int
+		want []int
+		err  string
+	}{
+		{0, []int{1, 2}, ""},
+		{1, []int{1}, ""},
+		{2, []int{2}, ""},
+		{3, nil, "bad part 3"},
+		{-1, nil, "bad part -1"},
+	} {
+		got, err := aoc.ParseParts(tt.part)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, tt.part)
+			continue
+		}
+		require.NoError(t, err, tt.part)
+		assert.Equal(t, tt.want, got, tt.part)
+	}
+}
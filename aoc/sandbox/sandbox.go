@@ -0,0 +1,152 @@
+// Package sandbox runs untrusted programs, like o1 attempts, with limits on CPU time, memory, open files
+// and written bytes, killing them after a wall clock timeout. On Linux they also get no network, where
+// network namespaces are available.
+//
+// Limits are set by the current executable itself: it's started again as a helper, which sets them,
+// and replaces itself with the program. So the limits hold from the first instruction of the program,
+// and the tool doesn't limit itself.
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Limits of a run. Zero ones are not set.
+type Limits struct {
+	Wall     time.Duration `json:"wall,omitempty"`      // killed after it
+	CPU      time.Duration `json:"cpu,omitempty"`       // of all threads, rounded up to seconds
+	Memory   int64         `json:"memory,omitempty"`    // address space, bytes
+	Files    int           `json:"files,omitempty"`     // open at once
+	FileSize int64         `json:"file_size,omitempty"` // of each written file, bytes
+}
+
+// Result of a run.
+type Result struct {
+	ExitCode  int           `json:"exit_code"`         // -1 if killed by a signal
+	Signal    string        `json:"signal,omitempty"`  // that killed it, like "killed" or "CPU time limit exceeded"
+	Timeout   bool          `json:"timeout,omitempty"` // killed after the wall or CPU limit
+	Time      time.Duration `json:"time_ns"`
+	CPU       time.Duration `json:"cpu_ns"`
+	MaxRSS    int64         `json:"max_rss"`              // peak resident memory, bytes
+	NoNetwork bool          `json:"no_network,omitempty"` // ran in an empty network namespace
+	Stdout    string        `json:"stdout,omitempty"`
+	Stderr    string        `json:"stderr,omitempty"`
+	Err       string        `json:"error,omitempty"` // if it couldn't be started
+}
+
+// MaxOutput of each stream kept from a run.
+const MaxOutput = 64 << 10
+
+// isolation of a run: process attributes, and if they take the network away.
+type isolation struct {
+	attr      *syscall.SysProcAttr
+	noNetwork bool
+}
+
+// helper is argv[0] of the executable started as the helper, and limitsEnv has limits for it.
+const (
+	helper    = "aoc-sandbox"
+	limitsEnv = "AOC_SANDBOX_LIMITS"
+)
+
+// Run runs the program with args in dir, with limits.
+func Run(ctx context.Context, dir string, limits Limits, program string, args ...string) Result {
+	self, err := os.Executable()
+	if err != nil {
+		return Result{ExitCode: -1, Err: err.Error()}
+	}
+	bs, err := json.Marshal(limits)
+	if err != nil {
+		return Result{ExitCode: -1, Err: err.Error()}
+	}
+	if limits.Wall > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Wall)
+		defer cancel()
+	}
+
+	// the first isolation that works, as namespaces may be not allowed
+	var res Result
+	for _, iso := range isolations() {
+		var stdout, stderr limitedBuffer
+		cmd := exec.CommandContext(ctx, self)
+		cmd.Args = append([]string{helper, program}, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), limitsEnv+"="+string(bs))
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		cmd.SysProcAttr = iso.attr
+		cmd.Cancel = func() error { return kill(cmd.Process) }
+		cmd.WaitDelay = time.Second
+		timeStart := time.Now()
+		if err := cmd.Start(); err != nil {
+			res = Result{ExitCode: -1, Err: err.Error()}
+			continue
+		}
+		err := cmd.Wait()
+		res = Result{
+			ExitCode:  cmd.ProcessState.ExitCode(),
+			Time:      time.Since(timeStart),
+			CPU:       cmd.ProcessState.UserTime() + cmd.ProcessState.SystemTime(),
+			NoNetwork: iso.noNetwork,
+			Stdout:    stdout.String(),
+			Stderr:    stderr.String(),
+			Timeout:   ctx.Err() != nil,
+		}
+		var cpu bool
+		res.Signal, res.MaxRSS, cpu = usage(cmd.ProcessState)
+		res.Timeout = res.Timeout || (cpu && limits.CPU > 0 && res.CPU >= limits.CPU)
+		if err != nil && !res.Timeout && res.ExitCode == -1 && res.Signal == "" {
+			res.Err = err.Error()
+		}
+		break
+	}
+	return res
+}
+
+// init turns the executable into the helper, if it was started as one. It never returns then.
+func init() {
+	if len(os.Args) < 2 || os.Args[0] != helper {
+		return
+	}
+	var limits Limits
+	err := json.Unmarshal([]byte(os.Getenv(limitsEnv)), &limits)
+	if err == nil {
+		err = setLimits(limits)
+	}
+	if err == nil {
+		env := slices.DeleteFunc(os.Environ(), func(kv string) bool { return strings.HasPrefix(kv, limitsEnv+"=") })
+		err = execve(os.Args[1], os.Args[1:], env, limits.Memory)
+	}
+	fmt.Fprintln(os.Stderr, "sandbox:", err)
+	os.Exit(127)
+}
+
+// limitedBuffer keeps the first MaxOutput bytes, and drops the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	dropped int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := min(len(p), MaxOutput-b.Len())
+	b.Buffer.Write(p[:n])
+	b.dropped += len(p) - n
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	if b.dropped > 0 {
+		return fmt.Sprintf("%s\n... %d bytes more", b.Buffer.String(), b.dropped)
+	}
+	return b.Buffer.String()
+}
@@ -0,0 +1,25 @@
+package sandbox
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// execve replaces the helper with the program. Memory is not limited, macOS doesn't enforce RLIMIT_AS.
+func execve(program string, argv, env []string, memory int64) error {
+	path, err := exec.LookPath(program)
+	if err != nil {
+		return err
+	}
+	return syscall.Exec(path, argv, env)
+}
+
+// isolations without namespaces, just a process group to kill.
+func isolations() []isolation {
+	return []isolation{{attr: &syscall.SysProcAttr{Setpgid: true}}}
+}
+
+// maxRSS in bytes, macOS has it in bytes already.
+func maxRSS(ru *syscall.Rusage) int64 {
+	return ru.Maxrss
+}
@@ -0,0 +1,38 @@
+//go:build !linux && !darwin
+
+package sandbox
+
+import (
+	"os"
+	"os/exec"
+)
+
+// isolations: none, and no limits either.
+func isolations() []isolation {
+	return []isolation{{}}
+}
+
+func setLimits(Limits) error {
+	return nil
+}
+
+// execve can't replace the helper here, so it waits for the program, and exits with its code.
+func execve(program string, argv, env []string, memory int64) error {
+	cmd := exec.Command(program, argv[1:]...)
+	cmd.Env = env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err := cmd.Run()
+	if cmd.ProcessState == nil {
+		return err
+	}
+	os.Exit(cmd.ProcessState.ExitCode())
+	return nil
+}
+
+func kill(p *os.Process) error {
+	return p.Kill()
+}
+
+func usage(*os.ProcessState) (string, int64, bool) {
+	return "", 0, false
+}
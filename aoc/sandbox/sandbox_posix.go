@@ -0,0 +1,53 @@
+//go:build linux || darwin
+
+package sandbox
+
+import (
+	"os"
+	"syscall"
+)
+
+// setLimits but memory, which is set by execve, as the helper itself may be over it.
+func setLimits(limits Limits) error {
+	set := func(resource int, soft, hard uint64) error {
+		return syscall.Setrlimit(resource, &syscall.Rlimit{Cur: soft, Max: hard})
+	}
+	if limits.CPU > 0 {
+		// SIGXCPU at the soft limit, SIGKILL a second later, if it's ignored
+		seconds := uint64((limits.CPU + 999_999_999) / 1_000_000_000)
+		if err := set(syscall.RLIMIT_CPU, seconds, seconds+1); err != nil {
+			return err
+		}
+	}
+	if limits.Files > 0 {
+		if err := set(syscall.RLIMIT_NOFILE, uint64(limits.Files), uint64(limits.Files)); err != nil {
+			return err
+		}
+	}
+	if limits.FileSize > 0 {
+		if err := set(syscall.RLIMIT_FSIZE, uint64(limits.FileSize), uint64(limits.FileSize)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kill the process group, with whatever the program started.
+func kill(p *os.Process) error {
+	return syscall.Kill(-p.Pid, syscall.SIGKILL)
+}
+
+// usage is the signal that killed the process, its peak RSS, and if the signal is one of the CPU limit.
+func usage(ps *os.ProcessState) (string, int64, bool) {
+	var signal string
+	var cpu bool
+	if ws, ok := ps.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		signal = ws.Signal().String()
+		cpu = ws.Signal() == syscall.SIGXCPU || ws.Signal() == syscall.SIGKILL // Go ignores SIGXCPU
+	}
+	var rss int64
+	if ru, ok := ps.SysUsage().(*syscall.Rusage); ok {
+		rss = maxRSS(ru)
+	}
+	return signal, rss, cpu
+}
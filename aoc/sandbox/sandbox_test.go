@@ -0,0 +1,59 @@
+package sandbox
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds programs")
+	}
+	dir := t.TempDir()
+	programs := map[string]string{
+		"spin":   "package main\n\nfunc main() {\n\tfor {\n\t}\n}\n",
+		"memory": "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tvar bs [][]byte\n\tfor {\n\t\tbs = append(bs, make([]byte, 1<<20))\n\t\tbs[len(bs)-1][0] = 1\n\t}\n\tfmt.Println(len(bs))\n}\n",
+		"write":  "package main\n\nimport \"os\"\n\nfunc main() {\n\terr := os.WriteFile(\"big.out\", make([]byte, 2<<20), 0644)\n\tif err != nil {\n\t\tpanic(err)\n\t}\n}\n",
+		"files":  "package main\n\nimport \"os\"\n\nfunc main() {\n\tfor range 100 {\n\t\tif _, err := os.Open(os.Args[0]); err != nil {\n\t\t\tpanic(err)\n\t\t}\n\t}\n}\n",
+		"net":    "package main\n\nimport \"net\"\n\nfunc main() {\n\tl, err := net.Listen(\"tcp\", \"127.0.0.1:0\")\n\tif err != nil {\n\t\tpanic(err)\n\t}\n\tc, err := net.Dial(\"tcp\", \"1.1.1.1:80\")\n\tif err != nil {\n\t\tpanic(err)\n\t}\n\tl.Close()\n\tc.Close()\n}\n",
+	}
+	bins := map[string]string{}
+	for name, src := range programs {
+		path := filepath.Join(dir, name+".go")
+		require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+		bins[name] = filepath.Join(dir, name)
+		cmd := exec.Command("go", "build", "-o", bins[name], path)
+		cmd.Env = append(os.Environ(), "GOWORK=off", "GOTOOLCHAIN=local")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%s: %s", name, out)
+	}
+	limits := Limits{Wall: 10 * time.Second, CPU: time.Second, Memory: 1 << 30, Files: 20, FileSize: 1 << 20}
+	run := func(name string) Result {
+		return Run(context.Background(), dir, limits, bins[name], "input.txt")
+	}
+
+	res := run("spin")
+	assert.True(t, res.Timeout, "CPU limit")
+	assert.Equal(t, -1, res.ExitCode)
+	assert.Less(t, res.CPU, 5*time.Second)
+	assert.Contains(t, run("memory").Stderr, "out of memory")
+	assert.Contains(t, run("write").Stderr, "file too large")
+	assert.Contains(t, run("files").Stderr, "too many open files")
+	if res := run("net"); res.NoNetwork {
+		assert.Contains(t, res.Stderr, "network is unreachable")
+	}
+
+	limits = Limits{Wall: 500 * time.Millisecond}
+	res = run("spin")
+	assert.True(t, res.Timeout, "wall limit")
+	assert.Equal(t, "killed", res.Signal)
+	assert.Less(t, res.Time, 2*time.Second)
+	assert.Positive(t, res.MaxRSS)
+}
@@ -0,0 +1,255 @@
+// Package search finds shortest paths in graphs of any state type:
+// BFS for unit steps, Dijkstra and A* for weighted steps,
+// and all optimal paths, as DAG of predecessors.
+//
+// Distances are kept in a map, or in a slice if Graph has Index,
+// which is much faster for grid days.
+package search
+
+import (
+	"container/heap"
+	"errors"
+	"iter"
+)
+
+// ErrNotFound is returned when the goal can't be reached.
+var ErrNotFound = errors.New("path not found")
+
+// Graph is defined by neighbors of each state.
+type Graph[S comparable] struct {
+	// Neighbors yields next states with cost of the step. BFS ignores costs.
+	Neighbors func(S) iter.Seq2[S, int]
+
+	// Index maps states to 0..Size-1, to keep distances in slices instead of maps. Optional.
+	Index func(S) int
+	Size  int
+}
+
+// Paths are the result of a search: distances from sources to reached states.
+type Paths[S comparable] struct {
+	index   func(S) int
+	dense   []int // -1 if not reached
+	sparse  map[S]int
+	reached []S // in order of distance
+
+	preds  [][]S // by index, only for AllPaths
+	spreds map[S][]S
+
+	goal     S
+	goalDist int
+	found    bool
+}
+
+func newPaths[S comparable](g Graph[S]) *Paths[S] {
+	p := &Paths[S]{index: g.Index}
+	if g.Index != nil {
+		p.dense = make([]int, g.Size)
+		for i := range p.dense {
+			p.dense[i] = -1
+		}
+	} else {
+		p.sparse = map[S]int{}
+	}
+	return p
+}
+
+// Dist returns distance to the state, and false if it wasn't reached.
+func (p *Paths[S]) Dist(s S) (int, bool) {
+	if p.dense != nil {
+		d := p.dense[p.index(s)]
+		return d, d >= 0
+	}
+	d, ok := p.sparse[s]
+	return d, ok
+}
+
+func (p *Paths[S]) set(s S, d int) {
+	if p.dense != nil {
+		p.dense[p.index(s)] = d
+	} else {
+		p.sparse[s] = d
+	}
+}
+
+// Reached states, in order of distance. Unlike Dist, these distances are final.
+func (p *Paths[S]) Reached() []S {
+	return p.reached
+}
+
+// Goal returns the first reached state the goal func accepted, and distance to it.
+// Returns ErrNotFound if there was none.
+func (p *Paths[S]) Goal() (S, int, error) {
+	if !p.found {
+		var zero S
+		return zero, -1, ErrNotFound
+	}
+	return p.goal, p.goalDist, nil
+}
+
+// Prev returns all predecessors of the state on optimal paths. Only for AllPaths.
+func (p *Paths[S]) Prev(s S) []S {
+	if p.preds != nil {
+		return p.preds[p.index(s)]
+	}
+	return p.spreds[s]
+}
+
+func (p *Paths[S]) addPrev(s, prev S, reset bool) {
+	if p.preds != nil {
+		i := p.index(s)
+		if reset {
+			p.preds[i] = p.preds[i][:0]
+		}
+		p.preds[i] = append(p.preds[i], prev)
+		return
+	}
+	if reset {
+		p.spreds[s] = p.spreds[s][:0]
+	}
+	p.spreds[s] = append(p.spreds[s], prev)
+}
+
+// DAG returns all states on optimal paths from sources to any of the targets,
+// walking predecessors back from targets. Only for AllPaths.
+func (p *Paths[S]) DAG(targets ...S) []S {
+	seen := map[S]bool{}
+	var states []S
+	for _, t := range targets {
+		if _, ok := p.Dist(t); ok && !seen[t] {
+			seen[t] = true
+			states = append(states, t)
+		}
+	}
+	for i := 0; i < len(states); i++ {
+		for _, prev := range p.Prev(states[i]) {
+			if !seen[prev] {
+				seen[prev] = true
+				states = append(states, prev)
+			}
+		}
+	}
+	return states
+}
+
+// BFS searches from sources with unit steps, until goal accepts a state. Goal can be nil, to reach everything.
+func BFS[S comparable](g Graph[S], sources []S, goal func(S) bool) *Paths[S] {
+	p := newPaths(g)
+	next := make([]S, 0, len(sources))
+	for _, s := range sources {
+		if _, ok := p.Dist(s); !ok {
+			p.set(s, 0)
+			next = append(next, s)
+		}
+	}
+	var cur []S
+	for d := 1; len(next) > 0; d++ {
+		cur, next = next, cur[:0]
+		for _, s := range cur {
+			p.reached = append(p.reached, s)
+			if goal != nil && goal(s) {
+				p.goal, p.goalDist, p.found = s, d-1, true
+				return p
+			}
+			for ns := range g.Neighbors(s) {
+				if _, ok := p.Dist(ns); ok {
+					continue
+				}
+				p.set(ns, d)
+				next = append(next, ns)
+			}
+		}
+	}
+	return p
+}
+
+// Dijkstra searches from sources with step costs, until goal accepts a state. Goal can be nil, to reach everything.
+// Costs must not be negative.
+func Dijkstra[S comparable](g Graph[S], sources []S, goal func(S) bool) *Paths[S] {
+	return search(g, newPaths(g), sources, goal, nil)
+}
+
+// AStar is Dijkstra guided by heuristic, which must not overestimate distance to the goal.
+func AStar[S comparable](g Graph[S], sources []S, goal func(S) bool, heuristic func(S) int) *Paths[S] {
+	return search(g, newPaths(g), sources, goal, heuristic)
+}
+
+// AllPaths is Dijkstra which keeps all predecessors on optimal paths, see Paths.Prev and Paths.DAG.
+// All states at the distance of the goal are reached, so the DAG has every optimal path to any of them.
+func AllPaths[S comparable](g Graph[S], sources []S, goal func(S) bool) *Paths[S] {
+	p := newPaths(g)
+	if g.Index != nil {
+		p.preds = make([][]S, g.Size)
+	} else {
+		p.spreds = map[S][]S{}
+	}
+	return search(g, p, sources, goal, nil)
+}
+
+func search[S comparable](g Graph[S], p *Paths[S], sources []S, goal func(S) bool, heuristic func(S) int) *Paths[S] {
+	all := p.preds != nil || p.spreds != nil
+	var q queue[S]
+	for _, s := range sources {
+		if _, ok := p.Dist(s); !ok {
+			p.set(s, 0)
+			q.push(s, 0, heuristic)
+		}
+	}
+	for q.Len() > 0 {
+		it := heap.Pop(&q).(item[S])
+		if d, _ := p.Dist(it.state); d < it.dist {
+			continue // already reached shorter
+		}
+		if p.found && it.dist > p.goalDist {
+			break
+		}
+		p.reached = append(p.reached, it.state)
+		if !p.found && goal != nil && goal(it.state) {
+			p.goal, p.goalDist, p.found = it.state, it.dist, true
+			if !all {
+				break
+			}
+		}
+		for ns, cost := range g.Neighbors(it.state) {
+			nd := it.dist + cost
+			d, ok := p.Dist(ns)
+			switch {
+			case !ok || nd < d:
+				p.set(ns, nd)
+				q.push(ns, nd, heuristic)
+				if all {
+					p.addPrev(ns, it.state, true)
+				}
+			case all && nd == d:
+				p.addPrev(ns, it.state, false)
+			}
+		}
+	}
+	return p
+}
+
+type item[S any] struct {
+	state S
+	dist  int
+	prio  int // dist + heuristic
+}
+
+type queue[S any] []item[S]
+
+func (q *queue[S]) push(s S, dist int, heuristic func(S) int) {
+	prio := dist
+	if heuristic != nil {
+		prio += heuristic(s)
+	}
+	heap.Push(q, item[S]{s, dist, prio})
+}
+
+func (q queue[S]) Len() int           { return len(q) }
+func (q queue[S]) Less(i, j int) bool { return q[i].prio < q[j].prio }
+func (q queue[S]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *queue[S]) Push(x any)        { *q = append(*q, x.(item[S])) }
+func (q *queue[S]) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
@@ -0,0 +1,175 @@
+package search
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+)
+
+// maze has several shortest paths from S to E, 5 steps each, and two cells off them.
+var maze = []string{
+	"S..#",
+	".#..",
+	"...E",
+	"#..#",
+}
+
+// onPaths are cells on shortest paths from S to E.
+var onPaths = []grid.Point{pt(0, 0), pt(1, 0), pt(2, 0), pt(0, 1), pt(2, 1), pt(3, 1), pt(0, 2), pt(1, 2), pt(2, 2), pt(3, 2)}
+
+func pt(x, y int) grid.Point {
+	return grid.Point{X: x, Y: y}
+}
+
+// mazeGraph of open cells, with distances in a slice if dense, or in a map.
+func mazeGraph(t *testing.T, dense bool) (Graph[grid.Point], grid.Point, grid.Point) {
+	m, err := grid.FromLines(maze)
+	require.NoError(t, err)
+	g := Graph[grid.Point]{Neighbors: func(p grid.Point) iter.Seq2[grid.Point, int] {
+		return func(yield func(grid.Point, int) bool) {
+			for n := range m.Neighbors4(p) {
+				if m.At(n) != '#' && !yield(n, 1) {
+					return
+				}
+			}
+		}
+	}}
+	if dense {
+		g.Index, g.Size = m.Index, m.W*m.H
+	}
+	start, _ := m.Find('S')
+	end, _ := m.Find('E')
+	return g, start, end
+}
+
+func TestMaze(t *testing.T) {
+	for _, dense := range []bool{false, true} {
+		g, start, end := mazeGraph(t, dense)
+		for _, tt := range []struct {
+			name   string
+			search func(Graph[grid.Point], []grid.Point, func(grid.Point) bool) *Paths[grid.Point]
+		}{
+			{"BFS", BFS[grid.Point]},
+			{"Dijkstra", Dijkstra[grid.Point]},
+			{"AStar", func(g Graph[grid.Point], sources []grid.Point, goal func(grid.Point) bool) *Paths[grid.Point] {
+				return AStar(g, sources, goal, end.Manhattan)
+			}},
+			{"AllPaths", AllPaths[grid.Point]},
+		} {
+			name := tt.name
+			if dense {
+				name += " dense"
+			}
+			paths := tt.search(g, []grid.Point{start}, func(p grid.Point) bool { return p == end })
+			goal, dist, err := paths.Goal()
+			require.NoError(t, err, name)
+			assert.Equal(t, end, goal, name)
+			assert.Equal(t, 5, dist, name)
+			d, ok := paths.Dist(end)
+			assert.True(t, ok, name)
+			assert.Equal(t, 5, d, name)
+
+			reached := paths.Reached()
+			assert.Equal(t, start, reached[0], name)
+			assert.Contains(t, reached, end, name)
+			assert.True(t, slices.IsSortedFunc(reached, func(a, b grid.Point) int {
+				da, _ := paths.Dist(a)
+				db, _ := paths.Dist(b)
+				return da - db
+			}), "%s: reached in order of distance", name)
+
+			// walls are not reached
+			for _, p := range []grid.Point{pt(3, 0), pt(1, 1), pt(0, 3)} {
+				_, ok := paths.Dist(p)
+				assert.False(t, ok, "%s: %v", name, p)
+			}
+			if tt.name == "AllPaths" {
+				assert.ElementsMatch(t, onPaths, paths.DAG(end), name)
+			}
+		}
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	for _, dense := range []bool{false, true} {
+		g, start, _ := mazeGraph(t, dense)
+		wall := pt(3, 3)
+		for name, paths := range map[string]*Paths[grid.Point]{
+			"BFS":      BFS(g, []grid.Point{start}, func(p grid.Point) bool { return p == wall }),
+			"Dijkstra": Dijkstra(g, []grid.Point{start}, func(p grid.Point) bool { return p == wall }),
+			"AllPaths": AllPaths(g, []grid.Point{start}, func(p grid.Point) bool { return p == wall }),
+		} {
+			_, dist, err := paths.Goal()
+			assert.ErrorIs(t, err, ErrNotFound, name)
+			assert.Equal(t, -1, dist, name)
+			assert.Len(t, paths.Reached(), 12, "%s: every open cell, looking for the goal", name)
+			_, ok := paths.Dist(wall)
+			assert.False(t, ok, name)
+			assert.Empty(t, paths.DAG(wall), name)
+		}
+	}
+}
+
+// weighted graph, where the direct step from 0 to 1 is longer than going around through 2.
+var weighted = Graph[int]{Neighbors: func(s int) iter.Seq2[int, int] {
+	edges := map[int][][2]int{
+		0: {{1, 10}, {2, 1}},
+		2: {{1, 2}},
+		1: {{3, 1}},
+	}
+	return func(yield func(int, int) bool) {
+		for _, e := range edges[s] {
+			if !yield(e[0], e[1]) {
+				return
+			}
+		}
+	}
+}}
+
+func TestWeighted(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		paths *Paths[int]
+		want  int
+	}{
+		{"BFS", BFS(weighted, []int{0}, func(s int) bool { return s == 3 }), 2}, // ignores costs
+		{"Dijkstra", Dijkstra(weighted, []int{0}, func(s int) bool { return s == 3 }), 4},
+		{"AStar", AStar(weighted, []int{0}, func(s int) bool { return s == 3 }, func(s int) int { return min(3-s, 1) }), 4},
+		{"AllPaths", AllPaths(weighted, []int{0}, func(s int) bool { return s == 3 }), 4},
+		{"many sources", Dijkstra(weighted, []int{0, 1}, func(s int) bool { return s == 3 }), 1},
+	} {
+		_, dist, err := tt.paths.Goal()
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want, dist, tt.name)
+	}
+}
+
+func TestDAG(t *testing.T) {
+	for _, dense := range []bool{false, true} {
+		g, start, end := mazeGraph(t, dense)
+		paths := AllPaths(g, []grid.Point{start}, nil)
+		dag := paths.DAG(end, end)
+		require.Len(t, dag, len(onPaths), "targets are not repeated")
+		assert.Equal(t, end, dag[0], "targets first")
+		assert.Equal(t, start, dag[len(dag)-1], "sources last")
+		for i, s := range dag {
+			d, _ := paths.Dist(s)
+			for _, prev := range paths.Prev(s) {
+				pd, _ := paths.Dist(prev)
+				assert.Equal(t, d-1, pd, "predecessors are one step closer")
+				assert.Greater(t, slices.Index(dag, prev), i, "predecessors come after %v", s)
+			}
+		}
+		assert.ElementsMatch(t, []grid.Point{pt(3, 1), pt(2, 2)}, paths.Prev(end))
+		assert.Empty(t, paths.Prev(start))
+
+		// to the bottom cell too, which has optimal paths through both cells off the paths to E
+		both := paths.DAG(end, pt(2, 3))
+		assert.Len(t, both, len(onPaths)+2)
+	}
+}
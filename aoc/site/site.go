@@ -0,0 +1,197 @@
+// Package site talks to adventofcode.com for cmd/fetch and cmd/submit, politely:
+// with the User-Agent of the repo, at most one request per Interval, and never
+// downloading what's already in the cache.
+package site
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/task"
+)
+
+const (
+	DefaultURL = "https://adventofcode.com"
+	Year       = 2024
+	// SessionEnv is the environment variable with the value of "session" cookie of the site.
+	SessionEnv = "AOC_SESSION"
+	UserAgent  = "github.com/metalim/adventofcode.2024.go"
+)
+
+// ErrNoSession is returned for requests which need to be logged in.
+var ErrNoSession = errors.New("no session, set " + SessionEnv + " to the session cookie of " + DefaultURL)
+
+// Client of the site. Zero values of fields are defaults, except Session.
+type Client struct {
+	URL      string // site root
+	Year     int
+	Session  string
+	CacheDir string        // responses are kept here, empty for no cache
+	Interval time.Duration // between requests, tracked in CacheDir, so between runs too
+	HTTP     *http.Client
+	Sleep    func(time.Duration) // waits for Interval, time.Sleep by default
+}
+
+// New is a client with session from SessionEnv and the default cache.
+func New() *Client {
+	c := &Client{Session: os.Getenv(SessionEnv), Interval: 5 * time.Second}
+	if dir, err := os.UserCacheDir(); err == nil {
+		c.CacheDir = filepath.Join(dir, "adventofcode")
+	}
+	return c
+}
+
+func (c *Client) year() int {
+	if c.Year == 0 {
+		return Year
+	}
+	return c.Year
+}
+
+// DayURL is the page of the day.
+func (c *Client) DayURL(day int) string {
+	root := c.URL
+	if root == "" {
+		root = DefaultURL
+	}
+	return fmt.Sprintf("%s/%d/day/%d", strings.TrimSuffix(root, "/"), c.year(), day)
+}
+
+// cachePath is where the file of the day is cached, or "" without cache.
+func (c *Client) cachePath(day int, name string) string {
+	if c.CacheDir == "" {
+		return ""
+	}
+	return filepath.Join(c.CacheDir, fmt.Sprint(c.year()), fmt.Sprintf("day%02d", day), name)
+}
+
+// Input of the day. It's the same forever, so it's downloaded only once.
+func (c *Client) Input(day int) ([]byte, error) {
+	return c.cached(day, "input.txt", nil, func() ([]byte, error) {
+		return c.Get(c.DayURL(day) + "/input")
+	})
+}
+
+// Page of the day, with the puzzle. Cached page is downloaded again only if it doesn't have part two yet,
+// which shows up after part one is solved.
+func (c *Client) Page(day int) ([]byte, error) {
+	complete := func(page []byte) bool {
+		return bytes.Contains(page, []byte(task.PartTwo))
+	}
+	return c.cached(day, "page.html", complete, func() ([]byte, error) {
+		return c.Get(c.DayURL(day))
+	})
+}
+
+// cached returns the cached file if it's complete, or fetches and caches it.
+// If fetching fails, incomplete cached file is still better than nothing.
+func (c *Client) cached(day int, name string, complete func([]byte) bool, fetch func() ([]byte, error)) ([]byte, error) {
+	path := c.cachePath(day, name)
+	var old []byte
+	if path != "" {
+		if bs, err := os.ReadFile(path); err == nil {
+			if complete == nil || complete(bs) {
+				return bs, nil
+			}
+			old = bs
+		}
+	}
+	bs, err := fetch()
+	if err != nil {
+		if old != nil {
+			return old, nil
+		}
+		return nil, err
+	}
+	if path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, bs, 0644); err != nil {
+			return nil, err
+		}
+	}
+	return bs, nil
+}
+
+// Get fetches the page, and fails on any status but 200.
+func (c *Client) Get(page string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, page, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// Post sends the form to the page, and fails on any status but 200.
+func (c *Client) Post(page string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodPost, page, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.Do(req)
+}
+
+// Do sends the request with the session cookie, waiting for Interval since the previous one.
+func (c *Client) Do(req *http.Request) ([]byte, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL, resp.Status, firstLine(body))
+	}
+	return body, nil
+}
+
+func firstLine(body []byte) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(string(body)), "\n")
+	return line
+}
+
+// wait sleeps until Interval passes since the last request, which is the time of the file in the cache dir.
+func (c *Client) wait() error {
+	if c.CacheDir == "" || c.Interval <= 0 {
+		return nil
+	}
+	path := filepath.Join(c.CacheDir, "last-request")
+	if info, err := os.Stat(path); err == nil {
+		if d := c.Interval - time.Since(info.ModTime()); d > 0 {
+			sleep := c.Sleep
+			if sleep == nil {
+				sleep = time.Sleep
+			}
+			sleep(d)
+		}
+	}
+	if err := os.MkdirAll(c.CacheDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(time.Now().Format(time.RFC3339)+"\n"), 0644)
+}
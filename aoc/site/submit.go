@@ -0,0 +1,91 @@
+package site
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/task"
+)
+
+// Verdict of the site on a submitted answer.
+type Verdict string
+
+const (
+	Correct Verdict = "correct"
+	TooHigh Verdict = "too high"
+	TooLow  Verdict = "too low"
+	Wrong   Verdict = "wrong" // without a hint
+	TooSoon Verdict = "too soon"
+	Solved  Verdict = "already solved" // or any level other than the one the day is at
+	Unknown Verdict = "unknown"
+)
+
+// IsWrong reports if the answer is known to be wrong.
+func (v Verdict) IsWrong() bool {
+	return v == TooHigh || v == TooLow || v == Wrong
+}
+
+// Reply of the site to a submitted answer.
+type Reply struct {
+	Verdict Verdict
+	Wait    time.Duration // before the next answer can be submitted
+	Text    string
+}
+
+// Submit posts the answer for the part of the day.
+func (c *Client) Submit(day, part int, answer string) (Reply, error) {
+	page, err := c.Post(c.DayURL(day)+"/answer", url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {answer},
+	})
+	if err != nil {
+		return Reply{}, err
+	}
+	return ParseReply(page)
+}
+
+var (
+	reLeftToWait = regexp.MustCompile(`You have ((?:\d+m ?)?(?:\d+s)?) left to wait`)
+	reWaitMinute = regexp.MustCompile(`(?i)wait (one|\d+) minutes? before trying again`)
+)
+
+// ParseReply reads the verdict from the page the site shows after submitting an answer.
+func ParseReply(page []byte) (Reply, error) {
+	text, err := task.Text(page)
+	if err != nil {
+		return Reply{}, err
+	}
+	r := Reply{Verdict: Unknown, Text: strings.TrimSpace(text)}
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		r.Verdict = Correct
+	case strings.Contains(text, "That's not the right answer"):
+		r.Verdict = Wrong
+		if strings.Contains(text, "your answer is too high") {
+			r.Verdict = TooHigh
+		} else if strings.Contains(text, "your answer is too low") {
+			r.Verdict = TooLow
+		}
+	case strings.Contains(text, "You gave an answer too recently"):
+		r.Verdict = TooSoon
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		r.Verdict = Solved
+	}
+	if m := reLeftToWait.FindStringSubmatch(text); m != nil {
+		r.Wait, err = time.ParseDuration(strings.ReplaceAll(m[1], " ", ""))
+		if err != nil {
+			return r, fmt.Errorf("bad wait %q: %w", m[1], err)
+		}
+	} else if m := reWaitMinute.FindStringSubmatch(text); m != nil {
+		minutes := 1
+		if m[1] != "one" {
+			minutes, _ = strconv.Atoi(m[1])
+		}
+		r.Wait = time.Duration(minutes) * time.Minute
+	}
+	return r, nil
+}
@@ -0,0 +1,81 @@
+package task
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var reArticle = regexp.MustCompile(`(?s)<article[^>]*>.*?</article>`)
+var reDay = regexp.MustCompile(`^--- Day \d+: .* ---\n`)
+
+// FromHTML converts the puzzle page to task.txt: the url, a blank line, and text of the puzzle parts.
+func FromHTML(url string, page []byte) (string, error) {
+	text, err := Text(page)
+	if err != nil {
+		return "", err
+	}
+	if !reDay.MatchString(text) {
+		return "", errors.New("no \"--- Day N: ... ---\" puzzle on the page")
+	}
+	return url + "\n\n" + text, nil
+}
+
+// Text is text of articles of the page, as if copied from the browser.
+// Paragraphs are separated by blank lines, code blocks and list items are lines.
+func Text(page []byte) (string, error) {
+	var sb strings.Builder
+	for _, article := range reArticle.FindAll(page, -1) {
+		if err := writeArticle(&sb, article); err != nil {
+			return "", err
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n") + "\n", nil
+}
+
+// writeArticle writes text of p, h2, pre and li elements. Whitespace between them is markup, not text.
+func writeArticle(sb *strings.Builder, article []byte) error {
+	d := xml.NewDecoder(strings.NewReader(string(article)))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+	var depth int // in elements with text
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("article: %w", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "p", "h2", "pre", "li":
+				depth++
+			}
+		case xml.EndElement:
+			switch tok.Name.Local {
+			case "p":
+				sb.WriteString("\n\n")
+			case "h2", "li":
+				sb.WriteString("\n")
+			case "pre":
+				if !strings.HasSuffix(sb.String(), "\n") {
+					sb.WriteString("\n")
+				}
+			default:
+				continue
+			}
+			depth--
+		case xml.CharData:
+			if depth > 0 {
+				sb.Write(tok)
+			}
+		}
+	}
+}
@@ -0,0 +1,308 @@
+// Package task pulls example inputs and their expected answers out of task.txt.
+//
+// task.txt is plain puzzle text, without code block markers, so everything here
+// is heuristics: examples are runs of non-prose lines, and answers are numbers
+// in sentences like "the answer should be 126384" or "a total distance of 11!".
+// Check what it finds before trusting it.
+package task
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const PartTwo = "--- Part Two ---"
+
+// Example is an example input from the task with answers it's given for each part.
+type Example struct {
+	Input   string
+	Answers map[int]string // part → expected answer
+
+	final map[int]bool // answer was given right before the block, and shouldn't be overwritten
+}
+
+// Examples returns example blocks from the task text, in order of appearance.
+// If like is not empty (usually content of input.txt), only blocks that look
+// like it are returned: same characters and same kind of sectioning.
+//
+// Blocks right after an explanation of the answer ("is made up of co, de, ka, and ta ... :")
+// are a part of it, not examples.
+//
+// An answer goes to the example the sentence refers to ("the first example",
+// "the larger example"), to the block that follows a sentence ending with it
+// ("This map has a total price of 368:"), to the block just above, or to the
+// last example mentioned, if diagrams were shown after it.
+func Examples(text, like string) []*Example {
+	var examples []*Example
+	var cur, last *Example // block just above, and last example mentioned
+	var intro []string     // prose since previous block
+	var pending string     // answer for the next block
+	var blocks int         // blocks seen in current part
+	var diagram bool       // block just above is not an example
+	var shown bool         // an example was shown in current part
+	part := 1
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == PartTwo:
+			part = 2
+			cur, intro, pending, blocks, diagram, shown = nil, nil, "", 0, false, false
+			i++
+
+		case line == "":
+			i++
+
+		case isProse(line):
+			intro = append(intro, line)
+			i++
+			about := cur
+			for _, sentence := range sentences(line) {
+				ref := refersTo(sentence, examples, last)
+				if diagram && !shown && reShort.MatchString(sentence) {
+					ref = nil // "in this short example" of part two is the diagram above, not an example of part one
+				}
+				if ref != nil {
+					about, last = ref, ref // following sentences of the paragraph are about it too
+				}
+				answer, ok := FindAnswer(sentence)
+				if !ok {
+					continue
+				}
+				if ref == nil && strings.HasSuffix(sentence, ":") {
+					// it's about the block that follows, but diagrams are not examples
+					if strings.HasSuffix(sentence, answer+":") {
+						pending = answer
+					}
+					if !reSummary.MatchString(sentence) {
+						continue
+					}
+				}
+				target := ref
+				if target == nil {
+					target = about
+				}
+				if target == nil && (blocks == 0 || reFallback.MatchString(sentence)) {
+					target = last
+				}
+				if target != nil && !target.final[part] {
+					target.Answers[part] = answer
+				}
+			}
+
+		default:
+			var block string
+			block, i = readBlock(lines, i)
+			blocks++
+			introduced := strings.Join(intro, " ")
+			answer := pending
+			intro, pending, cur = nil, "", nil
+			diagram = !looksLike(block, like) || reExplains.MatchString(introduced)
+			if diagram {
+				continue
+			}
+			shown = true
+			for _, ex := range examples {
+				if ex.Input == block {
+					cur = ex
+				}
+			}
+			if cur == nil {
+				cur = &Example{Input: block, Answers: map[int]string{}, final: map[int]bool{}}
+				examples = append(examples, cur)
+			}
+			if last == nil || reExample.MatchString(introduced) {
+				last = cur
+			}
+			if answer != "" {
+				cur.Answers[part] = answer
+				cur.final[part] = true
+			}
+		}
+	}
+	return examples
+}
+
+var (
+	reExample   = regexp.MustCompile(`(?i)\bexample\b`)
+	reShort     = regexp.MustCompile(`(?i)\bthis\s+(?:short|small|tiny)\s+example\b`)
+	reReference = regexp.MustCompile(`(?i)\b(first|second|third|original|larger|longer|bigger|smaller)\s+example\b`)
+	reSentence  = regexp.MustCompile(`[^.!?:;]+(?:[.!?:;]+|$)`)
+	ordinals    = map[string]int{"first": 0, "original": 0, "second": 1, "third": 2}
+)
+
+func sentences(line string) []string {
+	var ss []string
+	for _, s := range reSentence.FindAllString(line, -1) {
+		if s = strings.TrimSpace(s); s != "" {
+			ss = append(ss, s)
+		}
+	}
+	return ss
+}
+
+// refersTo finds the example mentioned in the sentence, like "the second example" or "this example".
+func refersTo(sentence string, examples []*Example, last *Example) *Example {
+	m := reReference.FindStringSubmatch(sentence)
+	if m == nil {
+		if reExample.MatchString(sentence) {
+			return last
+		}
+		return nil
+	}
+	if len(examples) == 0 {
+		return nil
+	}
+	word := strings.ToLower(m[1])
+	if n, ok := ordinals[word]; ok {
+		if n < len(examples) {
+			return examples[n]
+		}
+		return nil
+	}
+	// larger and smaller are relative to other examples
+	ex := examples[0]
+	for _, e := range examples[1:] {
+		if (len(e.Input) > len(ex.Input)) == (word != "smaller") {
+			ex = e
+		}
+	}
+	return ex
+}
+
+// readBlock reads non-prose lines from i, including blank lines inside, and returns index after the block.
+func readBlock(lines []string, i int) (string, int) {
+	var block []string
+	end := i
+	for j := i; j < len(lines); j++ {
+		line := lines[j]
+		if strings.TrimSpace(line) == PartTwo || isProse(line) {
+			break
+		}
+		block = append(block, strings.TrimRight(line, " \t"))
+		end = j + 1
+	}
+	for len(block) > 0 && block[len(block)-1] == "" {
+		block = block[:len(block)-1]
+	}
+	return strings.Join(block, "\n") + "\n", end
+}
+
+var reWord = regexp.MustCompile(`^[("'“]?[A-Za-z][A-Za-z'’-]*[.,;:!?)"'”]*$`)
+
+// isProse tells sentences from lines of examples and diagrams.
+func isProse(line string) bool {
+	line = strings.TrimSpace(line)
+	tokens := strings.Fields(line)
+	if len(tokens) >= 2 && strings.HasSuffix(line, ":") && line[0] >= 'A' && line[0] <= 'Z' {
+		return true // "For example:", "Move <:"
+	}
+	if len(tokens) < 4 {
+		return false
+	}
+	var words, long int
+	for _, t := range tokens {
+		if reWord.MatchString(t) {
+			words++
+			if len(strings.Trim(t, `.,;:!?()"'“”`)) >= 4 {
+				long++
+			}
+		}
+	}
+	if strings.ContainsAny(line[len(line)-1:], ".!?") && long >= 3 {
+		return true // "... is 31 (9 + 4 + 0 + 0 + 9 + 9)."
+	}
+	return words*10 >= len(tokens)*6 && long >= 2
+}
+
+// looksLike checks that block uses only characters of the input, and is sectioned like it.
+func looksLike(block, like string) bool {
+	if like == "" {
+		return true
+	}
+	like = strings.ReplaceAll(like, "\r\n", "\n")
+	// digits and letters go by class, as the input doesn't have to use all of them
+	class := func(r rune) rune {
+		switch {
+		case unicode.IsDigit(r):
+			return '0'
+		case unicode.IsLower(r):
+			return 'a'
+		case unicode.IsUpper(r):
+			return 'A'
+		}
+		return r
+	}
+	chars := map[rune]bool{}
+	for _, r := range like {
+		chars[class(r)] = true
+	}
+	// inputs made of random garbage don't have to use every character the example has
+	if len(chars) < 20 {
+		for _, r := range block {
+			if r != '\n' && !chars[class(r)] {
+				return false
+			}
+		}
+	}
+	sectioned := func(s string) bool { return strings.Contains(strings.TrimSpace(s), "\n\n") }
+	return sectioned(block) == sectioned(like)
+}
+
+// Answer is a number, or a comma separated list like "4,6,3,5" or "co,de,ka,ta".
+const answer = `([a-z0-9]+(?:,[a-z0-9]+)+|\d+)`
+
+var (
+	reAnswers = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\banswer (?:should|would|will) be ` + answer + `\b`),
+		regexp.MustCompile(`(?i)\b(?:is|are|be|of|to|produces?|gives?|yields?|get|has|have|take|visit|appears|occurs|costs?)\s+(?:only\s+|just\s+)?` + answer + `(?:[\s.,;:!)]|$)`),
+		regexp.MustCompile(`(?i)\b(?:is|equal to)\s+the\s+(?:decimal\s+)?number\s+` + answer + `\b`),
+		regexp.MustCompile(`(?i)\bexample,\s+` + answer + `\s+[a-z]`),
+		regexp.MustCompile(`(?i),\s+` + answer + `\s+[a-z]+\s+(?:are|is|were)\b`),
+		regexp.MustCompile(`(?i)\bexample\b.*,\s+` + answer + `[.!]$`),
+	}
+	// reNotAnswer skips numbers that are part of an expression, or a measure, like "is 4 * 10" or "is 84 picoseconds".
+	reNotAnswer = regexp.MustCompile(`^\s*[*+x×=-]\s*\d|^\s+(?:pico|nano)?seconds?\b`)
+	// reRange skips ranges, like "from 0 to 6".
+	reRange = regexp.MustCompile(`(?i)\bfrom\s+\d+\s+to\s+$`)
+	// reFallback marks sentences about the whole example, even if diagrams were shown after it.
+	reFallback = regexp.MustCompile(`(?i)\btotal\b|\bsum\b|\badding\b|\btogether\b|\bnarrows\b|\bshortest\b|\bconverting\b`)
+	// reExplains marks prose explaining the answer, with what it's made of listed after.
+	reExplains = regexp.MustCompile(`(?i)\bmade up of\b`)
+	// reSummary marks sentences with the answer, that still show some diagram after.
+	reSummary = regexp.MustCompile(`(?i)\badding\b|\btogether\b|\bnarrows\b`)
+)
+
+// FindAnswer returns the last answer-like number mentioned in the text.
+// Questions and conditionals are skipped, as they mention numbers from the real input
+// or made up ones.
+func FindAnswer(text string) (string, bool) {
+	var best string
+	var found bool
+	for _, sentence := range sentences(text) {
+		if strings.HasSuffix(sentence, "?") || strings.HasPrefix(sentence, "If ") {
+			continue
+		}
+		if answer, ok := lastAnswer(sentence); ok {
+			best, found = answer, true
+		}
+	}
+	return best, found
+}
+
+func lastAnswer(sentence string) (string, bool) {
+	var best string
+	bestAt := -1
+	for _, re := range reAnswers {
+		for _, m := range re.FindAllStringSubmatchIndex(sentence, -1) {
+			if reNotAnswer.MatchString(sentence[m[3]:]) || reRange.MatchString(sentence[:m[2]]) {
+				continue
+			}
+			if m[2] > bestAt {
+				best, bestAt = sentence[m[2]:m[3]], m[2]
+			}
+		}
+	}
+	return best, bestAt >= 0
+}
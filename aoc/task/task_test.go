@@ -0,0 +1,142 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAnswer(t *testing.T) {
+	for _, tt := range []struct {
+		text string
+		want string
+	}{
+		{"In this example, the answer should be 126384.", "126384"},
+		{"Adding them up gives a total distance of 11!", "11"},
+		{"So, in this example, the coordinates are 6,1.", "6,1"},
+		{"In this example, the password would be co,de,ka,ta.", "co,de,ka,ta"},
+		{"The final program output is 4,6,3,5,6,3,5,2,1,0.", "4,6,3,5,6,3,5,2,1,0"},
+		{"This map has a total price of 368:", "368"},
+		{"In this example, an X-MAS appears 9 times.", "9"},
+		{"The first is 3, and the second is 7.", "7"},
+		{"It is 4 * 10 in total.", ""},
+		{"The fastest time is 84 picoseconds.", ""},
+		{"Count from 0 to 6.", ""},
+		{"What is the sum of 5?", ""},
+		{"If the answer would be 42, you'd be done.", ""},
+		{"Nothing to see here.", ""},
+	} {
+		got, ok := FindAnswer(tt.text)
+		assert.Equal(t, tt.want != "", ok, tt.text)
+		assert.Equal(t, tt.want, got, tt.text)
+	}
+}
+
+func TestExamples(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		text string
+		like string
+		want []Example
+	}{
+		{
+			name: "block above",
+			text: "For example:\n\n1 2\n3 4\n\nIn this example, the total distance is 11.\n",
+			want: []Example{{Input: "1 2\n3 4\n", Answers: map[int]string{1: "11"}}},
+		},
+		{
+			name: "both parts",
+			text: "For example:\n\n1 2\n3 4\n\nIn this example, the total distance is 11.\n\n" + PartTwo +
+				"\n\nNow the similarity score of this example would be 31.\n",
+			want: []Example{{Input: "1 2\n3 4\n", Answers: map[int]string{1: "11", 2: "31"}}},
+		},
+		{
+			name: "block after",
+			text: "Here is a larger example. This map has a total price of 368:\n\nAAB\nABB\n\nThat is all there is to this region.\n",
+			want: []Example{{Input: "AAB\nABB\n", Answers: map[int]string{1: "368"}}},
+		},
+		{
+			name: "ordinals",
+			text: "Here is one example:\n\nAB\n\nHere is another example:\n\nABAB\nBABA\n\n" +
+				"The first example has a total price of 4. The second example has a total price of 80.\n",
+			want: []Example{
+				{Input: "AB\n", Answers: map[int]string{1: "4"}},
+				{Input: "ABAB\nBABA\n", Answers: map[int]string{1: "80"}},
+			},
+		},
+		{
+			name: "larger",
+			text: "Here is one example:\n\nAB\n\nHere is another example:\n\nABAB\nBABA\n\n" +
+				"The larger example has a total price of 80.\n",
+			want: []Example{
+				{Input: "AB\n", Answers: map[int]string{}},
+				{Input: "ABAB\nBABA\n", Answers: map[int]string{1: "80"}},
+			},
+		},
+		{
+			name: "like the input",
+			text: "For example:\n\n1 2\n3 4\n\nThe steps look like this:\n\n1 -> 2 -> x\n\nIn total, the answer is 11.\n",
+			like: "5 6\n7 8\n",
+			want: []Example{{Input: "1 2\n3 4\n", Answers: map[int]string{1: "11"}}},
+		},
+		{
+			name: "explanation",
+			text: "For example:\n\nka-co\nta-ka\n\n" + PartTwo + "\n\nThe largest set is made up of co, de, ka, and ta:\n\nco,de,ka,ta\n\n" +
+				"In this example, the password would be co,de,ka,ta.\n",
+			like: "ab-cd\n",
+			want: []Example{{Input: "ka-co\nta-ka\n", Answers: map[int]string{2: "co,de,ka,ta"}}},
+		},
+		{
+			name: "short example of part two is a diagram",
+			text: "For example:\n\n1\n10\n\nIn this example, the sum of the numbers is 37327623.\n\n" + PartTwo +
+				"\n\nIf a buyer starts with 123, the prices and changes are:\n\n123: 3\n15887950: 0 (-3)\n\n" +
+				"In this short example, the highest price will be 6. So that wins you 6 bananas.\n\n" +
+				"Suppose the initial secret numbers are:\n\n1\n2\n\nThe most bananas you could get is 23.\n",
+			like: "5\n7\n",
+			want: []Example{
+				{Input: "1\n10\n", Answers: map[int]string{1: "37327623"}},
+				{Input: "1\n2\n", Answers: map[int]string{2: "23"}},
+			},
+		},
+		{
+			name: "no answers",
+			text: "For example:\n\n1 2\n\nWhat is the total distance?\n",
+			want: []Example{{Input: "1 2\n", Answers: map[int]string{}}},
+		},
+		{
+			name: "crlf",
+			text: "For example:\r\n\r\n1 2\r\n3 4\r\n\r\nIn this example, the total distance is 11.\r\n",
+			want: []Example{{Input: "1 2\n3 4\n", Answers: map[int]string{1: "11"}}},
+		},
+	} {
+		var got []Example
+		for _, ex := range Examples(tt.text, tt.like) {
+			got = append(got, Example{Input: ex.Input, Answers: ex.Answers})
+		}
+		assert.Equal(t, tt.want, got, tt.name)
+	}
+}
+
+// TestDay22 checks that the diagram of part 2 doesn't give its answer to the example of part 1.
+func TestDay22(t *testing.T) {
+	text, err := os.ReadFile(filepath.Join("..", "..", "22", "task.txt"))
+	require.NoError(t, err)
+	like, err := os.ReadFile(filepath.Join("..", "..", "22", "input.txt"))
+	if os.IsNotExist(err) {
+		t.Skip("no input")
+	}
+	require.NoError(t, err)
+	answers := map[string]map[int]string{}
+	for _, ex := range Examples(string(text), string(like)) {
+		if len(ex.Answers) > 0 {
+			answers[ex.Input] = ex.Answers
+		}
+	}
+	assert.Equal(t, map[string]map[int]string{
+		"1\n10\n100\n2024\n": {1: "37327623"},
+		"1\n2\n3\n2024\n":    {2: "23"},
+	}, answers)
+}
@@ -0,0 +1,68 @@
+package vis
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// Flags registers -play, -cast, -gif, -png, -scale, -fps and -every on fs, and returns the recorder they set up.
+// Files are created on the first frame. Without any file, frames are played in the terminal.
+// Call Close after the day is done, to finish the files.
+func Flags(fs *flag.FlagSet, fps int) *Recorder {
+	var play bool
+	var every int
+	var castFile, gifFile, pngFile string
+	var scale int
+	fs.BoolVar(&play, "play", false, "play frames in the terminal, even if they are saved to files")
+	fs.StringVar(&castFile, "cast", "", "save frames as asciinema `file.cast`")
+	fs.StringVar(&gifFile, "gif", "", "save frames as animated `file.gif`")
+	fs.StringVar(&pngFile, "png", "", "save the last frame as `file.png`, or each frame if the name is like frame%04d.png")
+	fs.IntVar(&scale, "scale", 8, "pixels per cell in images")
+	fs.IntVar(&fps, "fps", fps, "frames per second")
+	fs.IntVar(&every, "every", 1, "record only every Nth frame, and the last one")
+
+	r := &Recorder{}
+	r.open = func() ([]Sink, error) {
+		r.Delay = time.Second / time.Duration(max(fps, 1))
+		r.Every = every
+		var sinks []Sink
+		for _, out := range []struct {
+			name string
+			sink func(*os.File) Sink
+		}{
+			{castFile, func(f *os.File) Sink { return NewCast(f) }},
+			{gifFile, func(f *os.File) Sink { return NewGIF(f, scale) }},
+		} {
+			if out.name == "" {
+				continue
+			}
+			file, err := os.Create(out.name)
+			if err != nil {
+				return sinks, err
+			}
+			sinks = append(sinks, closing{out.sink(file), file})
+		}
+		if pngFile != "" {
+			sinks = append(sinks, NewPNG(pngFile, scale))
+		}
+		if play || len(sinks) == 0 {
+			sinks = append(sinks, NewTerminal(color.Output))
+		}
+		return sinks, nil
+	}
+	return r
+}
+
+// closing closes the file after the sink.
+type closing struct {
+	Sink
+	file *os.File
+}
+
+func (c closing) Close() error {
+	return errors.Join(c.Sink.Close(), c.file.Close())
+}
@@ -0,0 +1,198 @@
+package vis
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+)
+
+// Palette of images: background, then colors in Color order. Default is drawn as White.
+var Palette = color.Palette{
+	color.RGBA{0x1e, 0x1e, 0x1e, 0xff}, // background
+	color.RGBA{0xc0, 0xc0, 0xc0, 0xff}, // Default
+	color.RGBA{0x00, 0x00, 0x00, 0xff},
+	color.RGBA{0xcd, 0x00, 0x00, 0xff},
+	color.RGBA{0x00, 0xcd, 0x00, 0xff},
+	color.RGBA{0xcd, 0xcd, 0x00, 0xff},
+	color.RGBA{0x00, 0x00, 0xee, 0xff},
+	color.RGBA{0xcd, 0x00, 0xcd, 0xff},
+	color.RGBA{0x00, 0xcd, 0xcd, 0xff},
+	color.RGBA{0xe5, 0xe5, 0xe5, 0xff},
+	color.RGBA{0x7f, 0x7f, 0x7f, 0xff},
+	color.RGBA{0xff, 0x00, 0x00, 0xff},
+	color.RGBA{0x00, 0xff, 0x00, 0xff},
+	color.RGBA{0xff, 0xff, 0x00, 0xff},
+	color.RGBA{0x5c, 0x5c, 0xff, 0xff},
+	color.RGBA{0xff, 0x00, 0xff, 0xff},
+	color.RGBA{0x00, 0xff, 0xff, 0xff},
+	color.RGBA{0xff, 0xff, 0xff, 0xff},
+}
+
+// quarters are block runes, drawn as their quadrants: bit 0 is top left, 1 top right, 2 bottom left, 3 bottom right.
+var quarters = []rune(" ▘▝▀▖▌▞▛▗▚▐▜▄▙▟█")
+
+// Image draws the frame with each cell as a square of scale pixels. There is no font,
+// so runes are drawn as shapes: space is empty, dots are small squares, blocks are blocks,
+// and anything else is a square a bit smaller than the cell.
+func (f Frame) Image(scale int) *image.Paletted {
+	scale = max(scale, 1)
+	img := image.NewPaletted(image.Rect(0, 0, f.W*scale, f.H*scale), Palette)
+	for p, c := range f.All() {
+		drawCell(img, p, c, scale)
+	}
+	return img
+}
+
+func drawCell(img *image.Paletted, p grid.Point, c Cell, scale int) {
+	index := uint8(c.Color) + 1
+	cell := image.Rect(p.X*scale, p.Y*scale, (p.X+1)*scale, (p.Y+1)*scale)
+	fill := func(r image.Rectangle) {
+		draw.Draw(img, r, image.NewUniform(Palette[index]), image.Point{}, draw.Src)
+	}
+	switch c.Ch {
+	case ' ':
+	case '.', '·', '•':
+		dot := max(scale/4, 1)
+		mid := cell.Min.Add(image.Pt(scale/2, scale/2))
+		fill(image.Rectangle{mid.Sub(image.Pt(dot/2, dot/2)), mid.Add(image.Pt(dot-dot/2, dot-dot/2))})
+	default:
+		for bits, q := range quarters {
+			if q != c.Ch {
+				continue
+			}
+			half := scale / 2
+			for i := range 4 {
+				if bits&(1<<i) != 0 {
+					at := cell.Min.Add(image.Pt(i%2*half, i/2*half))
+					fill(image.Rectangle{at, at.Add(image.Pt(scale-half, scale-half))})
+				}
+			}
+			return
+		}
+		if scale >= 4 {
+			cell = cell.Inset(1)
+		}
+		fill(cell)
+	}
+}
+
+// gifSink keeps only the changed part of each frame, as GIF allows frames smaller than the screen.
+type gifSink struct {
+	w     io.Writer
+	scale int
+	anim  gif.GIF
+	prev  *Frame
+	full  []bool // frame covers the screen of its size
+}
+
+// NewGIF writes frames to w as animated GIF, on Close. Each cell is a square of scale pixels.
+// Last frame is held for 2 seconds.
+func NewGIF(w io.Writer, scale int) Sink {
+	return &gifSink{w: w, scale: scale}
+}
+
+func (g *gifSink) Frame(f Frame, delay time.Duration) error {
+	r, full := image.Rect(0, 0, f.W, f.H), true
+	if g.prev != nil && g.prev.W == f.W && g.prev.H == f.H {
+		r, full = changed(*g.prev, f), false
+	}
+	g.prev = &f
+	scale := max(g.scale, 1)
+	img := image.NewPaletted(image.Rectangle{r.Min.Mul(scale), r.Max.Mul(scale)}, Palette)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			p := grid.Point{X: x, Y: y}
+			drawCell(img, p, f.At(p), scale)
+		}
+	}
+	g.anim.Image = append(g.anim.Image, img)
+	g.anim.Delay = append(g.anim.Delay, max(int(delay/(10*time.Millisecond)), 2))
+	g.anim.Disposal = append(g.anim.Disposal, gif.DisposalNone)
+	g.full = append(g.full, full)
+	return nil
+}
+
+// changed is the rectangle of cells which differ from prev. GIF can't have empty frames, so it's at least 1 cell.
+func changed(prev, f Frame) image.Rectangle {
+	var r image.Rectangle
+	for p, c := range f.All() {
+		if c != prev.At(p) {
+			r = r.Union(image.Rect(p.X, p.Y, p.X+1, p.Y+1))
+		}
+	}
+	if r.Empty() {
+		r = image.Rect(0, 0, 1, 1)
+	}
+	return r
+}
+
+func (g *gifSink) Close() error {
+	if len(g.anim.Image) == 0 {
+		return nil
+	}
+	var screen image.Rectangle
+	for _, img := range g.anim.Image {
+		screen = screen.Union(img.Bounds())
+	}
+	// smaller frame after a larger one must cover what's left of the larger one
+	for i, img := range g.anim.Image {
+		if g.full[i] && img.Bounds() != screen {
+			bg := image.NewPaletted(screen, Palette)
+			draw.Draw(bg, img.Bounds(), img, image.Point{}, draw.Src)
+			g.anim.Image[i] = bg
+		}
+	}
+	g.anim.Delay[len(g.anim.Delay)-1] = max(g.anim.Delay[len(g.anim.Delay)-1], 200)
+	g.anim.Config = image.Config{ColorModel: Palette, Width: screen.Dx(), Height: screen.Dy()}
+	return gif.EncodeAll(g.w, &g.anim)
+}
+
+type pngSink struct {
+	pattern string
+	scale   int
+	n       int
+	last    *Frame
+}
+
+// NewPNG saves the last frame to the file on Close. If the name has a verb like frame%04d.png,
+// each frame is saved to its own file instead, numbered from 1.
+func NewPNG(name string, scale int) Sink {
+	return &pngSink{pattern: name, scale: scale}
+}
+
+func (p *pngSink) Frame(f Frame, _ time.Duration) error {
+	p.n++
+	if !strings.Contains(p.pattern, "%") {
+		p.last = &f
+		return nil
+	}
+	return savePNG(fmt.Sprintf(p.pattern, p.n), f.Image(p.scale))
+}
+
+func (p *pngSink) Close() error {
+	if p.last == nil {
+		return nil
+	}
+	return savePNG(p.pattern, p.last.Image(p.scale))
+}
+
+func savePNG(name string, img image.Image) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
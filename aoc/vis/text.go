@@ -0,0 +1,210 @@
+package vis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+)
+
+const (
+	clearScreen = "\033[H\033[2J"
+	clearBelow  = "\033[J"
+	hideCursor  = "\033[?25l"
+	showCursor  = "\033[?25h"
+	resetColor  = "\033[0m"
+)
+
+// sgr is the escape code to switch to the color.
+func (c Color) sgr() string {
+	switch {
+	case c == Default:
+		return resetColor
+	case c <= White:
+		return fmt.Sprintf("\033[%dm", 30+c-Black)
+	}
+	return fmt.Sprintf("\033[%dm", 90+c-HiBlack)
+}
+
+// Fprint writes the frame as lines of text, followed by its caption. Colors are used if fatih/color would use them.
+func (f Frame) Fprint(w io.Writer) error {
+	var buf bytes.Buffer
+	cur := Default
+	for y := range f.H {
+		for x := range f.W {
+			c := f.At(grid.Point{X: x, Y: y})
+			if c.Color != cur && !color.NoColor {
+				buf.WriteString(c.Color.sgr())
+				cur = c.Color
+			}
+			buf.WriteRune(c.Ch)
+		}
+		if cur != Default {
+			buf.WriteString(resetColor)
+			cur = Default
+		}
+		buf.WriteByte('\n')
+	}
+	if f.Text != "" {
+		buf.WriteString(strings.TrimSuffix(f.Text, "\n"))
+		buf.WriteByte('\n')
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// screen draws frames in a terminal, redrawing only the cells which changed since the previous frame.
+type screen struct {
+	prev   Frame
+	drawn  bool
+	colors bool
+}
+
+func (s *screen) draw(buf *bytes.Buffer, f Frame) {
+	cur := Default
+	setColor := func(c Color) {
+		if s.colors && c != cur {
+			buf.WriteString(c.sgr())
+			cur = c
+		}
+	}
+	full := !s.drawn || s.prev.W != f.W || s.prev.H != f.H
+	if full {
+		buf.WriteString(clearScreen)
+		for y := range f.H {
+			for x := range f.W {
+				c := f.At(grid.Point{X: x, Y: y})
+				setColor(c.Color)
+				buf.WriteRune(c.Ch)
+			}
+			setColor(Default)
+			buf.WriteString("\r\n")
+		}
+	} else {
+		for y := range f.H {
+			next := -1 // column the cursor is at, if it's in this row
+			for x := range f.W {
+				p := grid.Point{X: x, Y: y}
+				c := f.At(p)
+				if c == s.prev.At(p) {
+					continue
+				}
+				if x != next {
+					fmt.Fprintf(buf, "\033[%d;%dH", y+1, x+1)
+				}
+				setColor(c.Color)
+				buf.WriteRune(c.Ch)
+				next = x + 1
+			}
+		}
+		setColor(Default)
+	}
+	if full || f.Text != s.prev.Text {
+		fmt.Fprintf(buf, "\033[%d;1H%s", f.H+1, clearBelow)
+		buf.WriteString(strings.ReplaceAll(f.Text, "\n", "\r\n"))
+	}
+	// leave the cursor below everything, in case something else is printed
+	fmt.Fprintf(buf, "\033[%d;1H", f.H+1+textLines(f.Text))
+	s.prev = f
+	s.drawn = true
+}
+
+func textLines(text string) int {
+	if text == "" {
+		return 0
+	}
+	return strings.Count(strings.TrimSuffix(text, "\n"), "\n") + 1
+}
+
+type terminal struct {
+	w io.Writer
+	screen
+	buf bytes.Buffer
+}
+
+// NewTerminal plays frames on the terminal as they come, waiting the delay after each.
+// Colors are used if fatih/color would use them.
+func NewTerminal(w io.Writer) Sink {
+	t := &terminal{w: w}
+	t.colors = !color.NoColor
+	return t
+}
+
+func (t *terminal) Frame(f Frame, delay time.Duration) error {
+	t.buf.Reset()
+	if !t.drawn {
+		t.buf.WriteString(hideCursor)
+	}
+	t.draw(&t.buf, f)
+	if _, err := t.w.Write(t.buf.Bytes()); err != nil {
+		return err
+	}
+	time.Sleep(delay)
+	return nil
+}
+
+func (t *terminal) Close() error {
+	if !t.drawn {
+		return nil
+	}
+	_, err := io.WriteString(t.w, showCursor)
+	return err
+}
+
+// cast is asciicast v2: a header line, and an [time, "o", data] line for each frame.
+// Header needs the size of the largest frame, so events are kept until Close.
+type cast struct {
+	w io.Writer
+	screen
+	events bytes.Buffer
+	buf    bytes.Buffer
+	time   time.Duration
+	width  int
+	height int
+}
+
+// NewCast writes frames to w as asciinema recording, on Close.
+func NewCast(w io.Writer) Sink {
+	c := &cast{w: w}
+	c.colors = true
+	return c
+}
+
+func (c *cast) Frame(f Frame, delay time.Duration) error {
+	c.buf.Reset()
+	c.draw(&c.buf, f)
+	event, err := json.Marshal([]any{c.time.Seconds(), "o", c.buf.String()})
+	if err != nil {
+		return err
+	}
+	c.events.Write(event)
+	c.events.WriteByte('\n')
+	c.time += delay
+	c.width = max(c.width, f.W)
+	c.height = max(c.height, f.H+1+textLines(f.Text))
+	return nil
+}
+
+func (c *cast) Close() error {
+	header, err := json.Marshal(map[string]any{
+		"version":   2,
+		"width":     max(c.width, 1),
+		"height":    max(c.height, 1),
+		"timestamp": time.Now().Unix(),
+		"env":       map[string]string{"TERM": "xterm-256color"},
+	})
+	if err != nil {
+		return err
+	}
+	header = append(header, '\n')
+	if _, err := c.w.Write(header); err != nil {
+		return err
+	}
+	_, err = c.w.Write(c.events.Bytes())
+	return err
+}
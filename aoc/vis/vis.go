@@ -0,0 +1,150 @@
+// Package vis records frames of grid visualizations, and plays them in the terminal,
+// or saves them as asciinema .cast, animated GIF, or PNG stills.
+//
+// Days build a Frame of colored runes and Add it to their Recorder. Destinations are chosen
+// by flags in the day's main, see Flags. Nil recorder is off.
+package vis
+
+import (
+	"errors"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+)
+
+// Color is one of 16 terminal colors, or the default one.
+type Color uint8
+
+const (
+	Default Color = iota
+	Black
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+	HiBlack
+	HiRed
+	HiGreen
+	HiYellow
+	HiBlue
+	HiMagenta
+	HiCyan
+	HiWhite
+)
+
+// Cell is a rune on the screen. Each rune takes one column.
+type Cell struct {
+	Ch    rune
+	Color Color
+}
+
+// Frame is a screen of cells, with a caption below it.
+type Frame struct {
+	grid.Grid[Cell]
+	Text string // not drawn on images
+}
+
+// NewFrame is a frame of spaces.
+func NewFrame(w, h int) Frame {
+	f := Frame{Grid: grid.New[Cell](w, h)}
+	f.Fill(Cell{Ch: ' '})
+	return f
+}
+
+// FromGrid makes a frame of the same size as the grid, with each cell drawn by cell func.
+func FromGrid[T comparable](g grid.Grid[T], cell func(grid.Point, T) Cell) Frame {
+	f := Frame{Grid: grid.New[Cell](g.W, g.H)}
+	for p, v := range g.All() {
+		f.Set(p, cell(p, v))
+	}
+	return f
+}
+
+// Sink is a destination of frames.
+type Sink interface {
+	// Frame shows the frame for delay. Sink can keep the frame, so it must not be changed after.
+	Frame(f Frame, delay time.Duration) error
+	Close() error
+}
+
+// Recorder sends frames to sinks. All methods do nothing on nil recorder,
+// but building frames takes time, so check On first.
+type Recorder struct {
+	Delay time.Duration // between frames
+	Every int           // record only every Nth frame, and the last one
+
+	sinks   []Sink
+	open    func() ([]Sink, error) // on the first frame, if sinks are given by flags
+	n       int
+	pending *Frame // last skipped frame
+	err     error
+}
+
+// New records frames to sinks, with delay between them.
+func New(delay time.Duration, sinks ...Sink) *Recorder {
+	return &Recorder{Delay: delay, sinks: sinks}
+}
+
+// On reports if frames are recorded at all.
+func (r *Recorder) On() bool {
+	return r != nil
+}
+
+// Add records the frame. Frame must not be changed after.
+// Errors stop the recording, and are returned by Close.
+func (r *Recorder) Add(f Frame) {
+	if r == nil || r.err != nil {
+		return
+	}
+	if r.open != nil {
+		r.sinks, r.err = r.open()
+		r.open = nil
+		if r.err != nil {
+			return
+		}
+	}
+	r.n++
+	if r.Every > 1 && (r.n-1)%r.Every != 0 {
+		r.pending = &f
+		return
+	}
+	r.pending = nil
+	r.send(f)
+}
+
+func (r *Recorder) send(f Frame) {
+	for _, s := range r.sinks {
+		if err := s.Frame(f, r.Delay); err != nil {
+			r.err = err
+			return
+		}
+	}
+}
+
+// Frames is the number of frames added so far, including skipped ones.
+func (r *Recorder) Frames() int {
+	if r == nil {
+		return 0
+	}
+	return r.n
+}
+
+// Close sends the last skipped frame, finishes the files, and returns the first error of the recording.
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	if r.pending != nil && r.err == nil {
+		r.send(*r.pending)
+		r.pending = nil
+	}
+	errs := []error{r.err}
+	for _, s := range r.sinks {
+		errs = append(errs, s.Close())
+	}
+	r.sinks = nil
+	return errors.Join(errs...)
+}
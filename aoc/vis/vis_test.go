@@ -0,0 +1,175 @@
+package vis
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/grid"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// golden compares got with the file in testdata, or writes it there with -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test ./aoc/vis -update to make it")
+	assert.Equal(t, string(want), string(got), name)
+}
+
+// sink keeps captions of frames it gets, and fails on the frame with failOn caption.
+type sink struct {
+	got    []string
+	failOn string
+	closed bool
+}
+
+func (s *sink) Frame(f Frame, delay time.Duration) error {
+	if f.Text == s.failOn {
+		return errors.New("failed on " + f.Text)
+	}
+	s.got = append(s.got, f.Text)
+	return nil
+}
+
+func (s *sink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func numbered(n int) Frame {
+	f := NewFrame(1, 1)
+	f.Text = fmt.Sprint(n)
+	return f
+}
+
+func TestRecorder(t *testing.T) {
+	for _, tt := range []struct {
+		every, frames int
+		sent          []string
+	}{
+		{0, 3, []string{"1", "2", "3"}},
+		{1, 3, []string{"1", "2", "3"}},
+		{3, 7, []string{"1", "4", "7"}},      // the last one is sent anyway
+		{3, 8, []string{"1", "4", "7", "8"}}, // pending frame is sent on Close
+		{3, 9, []string{"1", "4", "7", "9"}}, // only the last pending one
+		{10, 1, []string{"1"}},
+		{10, 0, nil},
+	} {
+		s := &sink{}
+		r := New(time.Millisecond, s)
+		r.Every = tt.every
+		for i := range tt.frames {
+			r.Add(numbered(i + 1))
+		}
+		assert.Equal(t, tt.frames, r.Frames())
+		require.NoError(t, r.Close())
+		assert.Equal(t, tt.sent, s.got, "every %d of %d", tt.every, tt.frames)
+		assert.True(t, s.closed)
+	}
+}
+
+func TestRecorderError(t *testing.T) {
+	s, other := &sink{failOn: "2"}, &sink{}
+	r := New(0, s, other)
+	for i := range 4 {
+		r.Add(numbered(i + 1))
+	}
+	assert.Equal(t, []string{"1"}, s.got)
+	assert.Equal(t, []string{"1"}, other.got, "nothing is sent after the error")
+	assert.EqualError(t, r.Close(), "failed on 2")
+	assert.True(t, s.closed)
+	assert.True(t, other.closed)
+
+	// skipped frame that fails on Close
+	s = &sink{failOn: "4"}
+	r = New(0, s)
+	r.Every = 3
+	for i := range 4 {
+		r.Add(numbered(i + 1))
+	}
+	assert.EqualError(t, r.Close(), "failed on 4")
+
+	var off *Recorder
+	assert.False(t, off.On())
+	off.Add(numbered(1))
+	assert.Equal(t, 0, off.Frames())
+	assert.NoError(t, off.Close())
+}
+
+// frames of a small animation: a dot moving over a colored map, then a larger frame.
+func frames() []Frame {
+	var fs []Frame
+	for i := range 3 {
+		f := NewFrame(4, 3)
+		for x := range 4 {
+			f.Set(grid.Point{X: x, Y: 0}, Cell{'#', Red})
+		}
+		f.Set(grid.Point{X: 0, Y: 2}, Cell{'▚', Green})
+		f.Set(grid.Point{X: 3, Y: 2}, Cell{'█', Default})
+		f.Set(grid.Point{X: i, Y: 1}, Cell{'.', HiYellow})
+		f.Text = fmt.Sprintf("step %d", i)
+		fs = append(fs, f)
+	}
+	big := NewFrame(5, 4)
+	big.Fill(Cell{'▖', Blue})
+	big.Text = "done\nreally"
+	return append(fs, big)
+}
+
+var reTimestamp = regexp.MustCompile(`"timestamp":\d+`)
+
+func TestCast(t *testing.T) {
+	var b bytes.Buffer
+	r := New(100*time.Millisecond, NewCast(&b))
+	for _, f := range frames() {
+		r.Add(f)
+	}
+	require.NoError(t, r.Close())
+	golden(t, "frames.cast", reTimestamp.ReplaceAll(b.Bytes(), []byte(`"timestamp":0`)))
+}
+
+func TestPNG(t *testing.T) {
+	dir := t.TempDir()
+	last := filepath.Join(dir, "last.png")
+	each := filepath.Join(dir, "frame%02d.png")
+	r := New(0, NewPNG(last, 4), NewPNG(each, 4))
+	fs := frames()
+	for _, f := range fs[:3] {
+		r.Add(f)
+	}
+	require.NoError(t, r.Close())
+
+	for i, name := range []string{"frame01.png", "frame02.png", "frame03.png"} {
+		assert.Equal(t, fs[i].Image(4), decode(t, filepath.Join(dir, name)), name)
+	}
+	bs, err := os.ReadFile(last)
+	require.NoError(t, err)
+	golden(t, "frame.png", bs)
+	assert.Equal(t, fs[2].Image(4), decode(t, filepath.Join("testdata", "frame.png")))
+}
+
+func decode(t *testing.T, path string) image.Image {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	img, err := png.Decode(file)
+	require.NoError(t, err)
+	return img
+}
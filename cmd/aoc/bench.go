@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+)
+
+// BenchRun is one run of benchmarks, as stored in the history file.
+type BenchRun struct {
+	Time    time.Time `json:"time"`
+	Commit  string    `json:"commit,omitempty"`
+	Go      string    `json:"go"`
+	Input   string    `json:"input"`
+	Results []Bench   `json:"results"`
+}
+
+// Bench is the result of one part.
+type Bench struct {
+	Day         int    `json:"day"`
+	Part        int    `json:"part"`
+	N           int    `json:"n"`
+	NsPerOp     int64  `json:"ns_op"`
+	BytesPerOp  int64  `json:"bytes_op"`
+	AllocsPerOp int64  `json:"allocs_op"`
+	Error       string `json:"error,omitempty"`
+}
+
+type benchFlags struct {
+	root           string
+	days           string
+	part           int
+	input          string
+	history        string
+	benchtime      string
+	threshold      float64
+	allocThreshold float64
+	save           bool
+}
+
+func (f *benchFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.root, "root", ".", "repository root with day folders")
+	fs.StringVar(&f.days, "day", "all", "days to benchmark, like 5, 5-12 or 1,3,20-")
+	fs.IntVar(&f.part, "part", 0, "part to benchmark, 0 for both")
+	fs.StringVar(&f.input, "input", "input.txt", "input file name in the day folder")
+	fs.StringVar(&f.history, "history", "bench.json", "history file, relative to root unless absolute")
+	fs.StringVar(&f.benchtime, "benchtime", "1s", "run each part for this long, or Nx times")
+	fs.Float64Var(&f.threshold, "threshold", 20, "percent of time/op over baseline to flag as slower")
+	fs.Float64Var(&f.allocThreshold, "alloc-threshold", 10, "percent of allocs/op or B/op over baseline to flag")
+	fs.BoolVar(&f.save, "save", false, "append this run to the history, to be the next baseline")
+}
+
+// benchCmd benchmarks parts in-process and compares them with their latest results saved in the history.
+// Exits with 1 if anything got slower or allocates more than thresholds allow.
+func benchCmd(args []string) {
+	var f benchFlags
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	f.register(fs)
+	fs.Parse(args)
+
+	days, err := aoc.ParseDays(f.days)
+	catch(err)
+	parts, err := aoc.ParseParts(f.part)
+	catch(err)
+	testing.Init()
+	catch(flag.Set("test.benchtime", f.benchtime))
+
+	historyPath := f.history
+	if !filepath.IsAbs(historyPath) {
+		historyPath = filepath.Join(f.root, historyPath)
+	}
+	history, err := loadHistory(historyPath)
+	catch(err)
+
+	run := BenchRun{
+		Time:   time.Now().UTC().Truncate(time.Second),
+		Commit: gitCommit(f.root),
+		Go:     runtime.Version(),
+		Input:  f.input,
+	}
+	for _, day := range days {
+		puzzle, _ := aoc.Get(day)
+		run.Results = append(run.Results, benchDay(puzzle, f.root, f.input, parts)...)
+	}
+
+	regressions := printBench(os.Stdout, run, baselines(history), f.threshold, f.allocThreshold)
+	if f.save {
+		catch(saveHistory(historyPath, append(history, run)))
+		fmt.Println("Saved to", historyPath)
+	}
+	if regressions > 0 {
+		fmt.Printf("%d regressions\n", regressions)
+		os.Exit(1)
+	}
+}
+
+func benchDay(puzzle aoc.Puzzle, root, input string, parts []int) []Bench {
+	var results []Bench
+	fail := func(err error) []Bench {
+		for _, part := range parts {
+			results = append(results, Bench{Day: puzzle.Day, Part: part, Error: err.Error()})
+		}
+		return results
+	}
+	bs, err := os.ReadFile(filepath.Join(root, aoc.Dir(puzzle.Day), input))
+	if err != nil {
+		return fail(err)
+	}
+	log := aoc.Log
+	aoc.Log = io.Discard
+	parsed, err := puzzle.Parse(string(bs))
+	aoc.Log = log
+	if err != nil {
+		return fail(err)
+	}
+	ctx := aoc.WithInput(context.Background(), input)
+	for _, part := range parts {
+		if part > len(puzzle.Parts()) {
+			continue
+		}
+		b := Bench{Day: puzzle.Day, Part: part}
+		res := testing.Benchmark(aoctest.BenchPart(ctx, puzzle, parsed, part))
+		if res.N == 0 {
+			// benchmark failed, run once to see why
+			_, err := puzzle.Solve(ctx, parsed, part)
+			if err == nil {
+				err = errors.New("benchmark failed")
+			}
+			b.Error = err.Error()
+		} else {
+			b.N = res.N
+			b.NsPerOp = res.NsPerOp()
+			b.BytesPerOp = res.AllocedBytesPerOp()
+			b.AllocsPerOp = res.AllocsPerOp()
+		}
+		results = append(results, b)
+	}
+	return results
+}
+
+type benchKey struct {
+	day, part int
+	input     string
+}
+
+// baseline is a saved result of a part, with the run it's from.
+type baseline struct {
+	Bench
+	Go     string
+	Commit string
+	Time   time.Time
+}
+
+// baselines are the latest results of each part on each input saved in the history, errors aside.
+func baselines(history []BenchRun) map[benchKey]baseline {
+	base := map[benchKey]baseline{}
+	for _, run := range history {
+		for _, b := range run.Results {
+			key := benchKey{b.Day, b.Part, run.Input}
+			if b.Error == "" && !run.Time.Before(base[key].Time) {
+				base[key] = baseline{Bench: b, Go: run.Go, Commit: run.Commit, Time: run.Time}
+			}
+		}
+	}
+	return base
+}
+
+// printBench prints results next to their baselines and returns number of regressions.
+// Baselines of other Go versions are shown, but not compared.
+func printBench(w io.Writer, run BenchRun, base map[benchKey]baseline, threshold, allocThreshold float64) int {
+	var regressions int
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tPart\tTime/op\tΔ\tB/op\tΔ\tAllocs/op\tΔ\tBaseline\tStatus")
+	for _, b := range run.Results {
+		if b.Error != "" {
+			fmt.Fprintf(tw, "%d\t%d\t\t\t\t\t\t\t\terror: %s\n", b.Day, b.Part, b.Error)
+			continue
+		}
+		old, ok := base[benchKey{b.Day, b.Part, run.Input}]
+		var status []string
+		from := ""
+		if ok {
+			from = strings.TrimSpace(old.Time.Format(time.DateOnly) + " " + old.Commit)
+		}
+		switch {
+		case !ok:
+			status = append(status, "new")
+		case old.Go != run.Go:
+			status = append(status, "not compared, baseline is on "+old.Go)
+			ok = false
+		default:
+			if over(b.NsPerOp, old.NsPerOp, threshold) {
+				status = append(status, "SLOWER")
+			}
+			if over(b.BytesPerOp, old.BytesPerOp, allocThreshold) || over(b.AllocsPerOp, old.AllocsPerOp, allocThreshold) {
+				status = append(status, "MORE ALLOCS")
+			}
+			if len(status) > 0 {
+				regressions++
+			} else {
+				status = append(status, "ok")
+			}
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%v\t%s\t%d\t%s\t%d\t%s\t%s\t%s\n", b.Day, b.Part,
+			time.Duration(b.NsPerOp), delta(b.NsPerOp, old.NsPerOp, ok),
+			b.BytesPerOp, delta(b.BytesPerOp, old.BytesPerOp, ok),
+			b.AllocsPerOp, delta(b.AllocsPerOp, old.AllocsPerOp, ok),
+			from, strings.Join(status, ", "))
+	}
+	tw.Flush()
+	return regressions
+}
+
+// over tells if v is more than percent over base.
+func over(v, base int64, percent float64) bool {
+	return float64(v) > float64(base)*(1+percent/100)
+}
+
+func delta(v, base int64, ok bool) string {
+	switch {
+	case !ok:
+		return ""
+	case base == 0 && v == 0:
+		return "~"
+	case base == 0:
+		return "+inf"
+	}
+	return fmt.Sprintf("%+.1f%%", (float64(v)/float64(base)-1)*100)
+}
+
+func loadHistory(path string) ([]BenchRun, error) {
+	bs, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var history []BenchRun
+	if err := json.Unmarshal(bs, &history); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return history, nil
+}
+
+func saveHistory(path string, history []BenchRun) error {
+	bs, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(bs, '\n'), 0644)
+}
+
+// gitCommit returns short hash of HEAD, with "-dirty" if there are changes. Empty if not in git.
+func gitCommit(root string) string {
+	out, err := exec.Command("git", "-C", root, "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	commit := strings.TrimSpace(string(out))
+	out, err = exec.Command("git", "-C", root, "status", "--porcelain", "--untracked-files=no").Output()
+	if err == nil && len(out) > 0 {
+		commit += "-dirty"
+	}
+	return commit
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(day int) time.Time {
+	return time.Date(2024, 12, day, 0, 0, 0, 0, time.UTC)
+}
+
+var history = []BenchRun{
+	{Time: at(2), Commit: "bbb", Go: "go1.23.3", Input: "input.txt", Results: []Bench{
+		{Day: 1, Part: 1, N: 10, NsPerOp: 200},
+		{Day: 1, Part: 2, Error: "nope"},
+	}},
+	{Time: at(1), Commit: "aaa", Go: "go1.23.3", Input: "input.txt", Results: []Bench{
+		{Day: 1, Part: 1, N: 10, NsPerOp: 100}, // older, saved after
+		{Day: 1, Part: 2, N: 10, NsPerOp: 300},
+	}},
+	{Time: at(3), Commit: "ccc", Go: "go1.22.0", Input: "input2.txt", Results: []Bench{
+		{Day: 1, Part: 1, N: 10, NsPerOp: 50},
+	}},
+}
+
+func TestBaselines(t *testing.T) {
+	base := baselines(history)
+	assert.Equal(t, map[benchKey]baseline{
+		{1, 1, "input.txt"}:  {Bench: history[0].Results[0], Go: "go1.23.3", Commit: "bbb", Time: at(2)},
+		{1, 2, "input.txt"}:  {Bench: history[1].Results[1], Go: "go1.23.3", Commit: "aaa", Time: at(1)}, // the latest is an error
+		{1, 1, "input2.txt"}: {Bench: history[2].Results[0], Go: "go1.22.0", Commit: "ccc", Time: at(3)},
+	}, base)
+	assert.Empty(t, baselines(nil))
+}
+
+func TestPrintBench(t *testing.T) {
+	base := baselines(history)
+	for _, tt := range []struct {
+		name        string
+		input       string
+		bench       Bench
+		line        string
+		regressions int
+	}{
+		{"same", "input.txt", Bench{Day: 1, Part: 1, NsPerOp: 200}, "1 1 200ns +0.0% 0 ~ 0 ~ 2024-12-02 bbb ok", 0},
+		{"within threshold", "input.txt", Bench{Day: 1, Part: 1, NsPerOp: 240}, "1 1 240ns +20.0% 0 ~ 0 ~ 2024-12-02 bbb ok", 0},
+		{"faster", "input.txt", Bench{Day: 1, Part: 1, NsPerOp: 100}, "1 1 100ns -50.0% 0 ~ 0 ~ 2024-12-02 bbb ok", 0},
+		{"slower", "input.txt", Bench{Day: 1, Part: 1, NsPerOp: 241}, "1 1 241ns +20.5% 0 ~ 0 ~ 2024-12-02 bbb SLOWER", 1},
+		{"more bytes", "input.txt", Bench{Day: 1, Part: 1, NsPerOp: 200, BytesPerOp: 1}, "1 1 200ns +0.0% 1 +inf 0 ~ 2024-12-02 bbb MORE ALLOCS", 1},
+		{"more allocs", "input.txt", Bench{Day: 1, Part: 2, NsPerOp: 400, AllocsPerOp: 1}, "1 2 400ns +33.3% 0 ~ 1 +inf 2024-12-01 aaa SLOWER, MORE ALLOCS", 1},
+		{"new", "input.txt", Bench{Day: 2, Part: 1, NsPerOp: 1}, "2 1 1ns 0 0 new", 0},
+		{"other go", "input2.txt", Bench{Day: 1, Part: 1, NsPerOp: 1000}, "1 1 1µs 0 0 2024-12-03 ccc not compared, baseline is on go1.22.0", 0},
+		{"error", "input.txt", Bench{Day: 1, Part: 1, Error: "boom"}, "1 1 error: boom", 0},
+	} {
+		var out strings.Builder
+		run := BenchRun{Go: "go1.23.3", Input: tt.input, Results: []Bench{tt.bench}}
+		assert.Equal(t, tt.regressions, printBench(&out, run, base, 20, 10), tt.name)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2, tt.name)
+		assert.Equal(t, tt.line, strings.Join(strings.Fields(lines[1]), " "), tt.name)
+	}
+}
+
+func TestOverDelta(t *testing.T) {
+	for _, tt := range []struct {
+		v, base int64
+		over    bool
+		delta   string
+	}{
+		{100, 100, false, "+0.0%"},
+		{120, 100, false, "+20.0%"},
+		{121, 100, true, "+21.0%"},
+		{50, 100, false, "-50.0%"},
+		{0, 0, false, "~"},
+		{1, 0, true, "+inf"},
+	} {
+		assert.Equal(t, tt.over, over(tt.v, tt.base, 20), "%d over %d", tt.v, tt.base)
+		assert.Equal(t, tt.delta, delta(tt.v, tt.base, true), "%d over %d", tt.v, tt.base)
+		assert.Empty(t, delta(tt.v, tt.base, false), "no baseline")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench.json")
+	got, err := loadHistory(path)
+	require.NoError(t, err)
+	assert.Nil(t, got, "no history yet")
+
+	require.NoError(t, saveHistory(path, history))
+	got, err = loadHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, history, got)
+
+	require.NoError(t, saveHistory(path, append(got, BenchRun{Time: at(4), Go: "go1.23.3", Input: "input.txt"})))
+	got, err = loadHistory(path)
+	require.NoError(t, err)
+	assert.Len(t, got, 4)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+	_, err = loadHistory(path)
+	assert.ErrorContains(t, err, path+": ")
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+)
+
+// Status of a part on an input, against the recorded answer.
+type Status string
+
+const (
+	Pass        Status = "ok"
+	Fail        Status = "FAIL"
+	Panic       Status = "PANIC"
+	Errored     Status = "ERROR"
+	Timeout     Status = "timeout"
+	Unsupported Status = "not supported"
+	Unknown     Status = "?" // no recorded answer
+)
+
+// Check is a result with the recorded answer it's checked against.
+type Check struct {
+	aoc.Result
+	Expected string `json:"expected,omitempty"`
+	Status   Status `json:"status"`
+}
+
+type inputsFlags struct {
+	root     string
+	days     string
+	part     int
+	samples  bool
+	parallel int
+	json     bool
+	timeout  time.Duration
+}
+
+func (f *inputsFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.root, "root", ".", "repository root with day folders")
+	fs.StringVar(&f.days, "day", "all", "days to run, like 5, 5-12 or 1,3,20-")
+	fs.IntVar(&f.part, "part", 0, "part to run, 0 for both")
+	fs.BoolVar(&f.samples, "samples", false, "run sample files too")
+	fs.IntVar(&f.parallel, "parallel", 1, "number of days to run at once")
+	fs.BoolVar(&f.json, "json", false, "print results as JSON")
+	fs.DurationVar(&f.timeout, "timeout", 0, "time limit per part, 0 for none. None by default, as some parts take over 10s")
+}
+
+// inputsCmd runs days on every input of the day folder, to catch solutions that work only for one input.
+func inputsCmd(args []string) {
+	var f inputsFlags
+	fs := flag.NewFlagSet("inputs", flag.ExitOnError)
+	f.register(fs)
+	fs.Parse(args)
+
+	days, err := aoc.ParseDays(f.days)
+	catch(err)
+	parts, err := aoc.ParseParts(f.part)
+	catch(err)
+	aoc.Log = io.Discard
+
+	results := runDays(days, f.parallel, func(p aoc.Puzzle) []aoc.Result {
+		inputs, err := aoc.Inputs(f.root, p.Day)
+		catch(err)
+		var results []aoc.Result
+		for _, input := range inputs {
+			if !f.samples && strings.HasPrefix(input[strings.LastIndex(input, "/")+1:], "sample") {
+				continue
+			}
+			results = append(results, p.RunFile(context.Background(), f.root, input, f.timeout, parts...)...)
+		}
+		return results
+	})
+	checks := checkResults(f.root, results)
+
+	if f.json {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		catch(enc.Encode(checks))
+		return
+	}
+	counts := printChecks(os.Stdout, checks, parts)
+	var summary []string
+	for _, s := range []Status{Pass, Fail, Panic, Errored, Timeout, Unsupported, Unknown} {
+		if counts[s] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[s], s))
+		}
+	}
+	fmt.Println(strings.Join(summary, ", "))
+	if counts[Fail]+counts[Panic]+counts[Errored] > 0 {
+		os.Exit(1)
+	}
+}
+
+// checkResults compares results with answers recorded in answers.json of each day.
+func checkResults(root string, results []aoc.Result) []Check {
+	answers := map[int]aoc.Answers{}
+	checks := make([]Check, len(results))
+	for i, r := range results {
+		if _, ok := answers[r.Day]; !ok {
+			a, err := aoc.LoadAnswers(root, r.Day)
+			catch(err)
+			answers[r.Day] = a
+		}
+		expected, ok := answers[r.Day].Get(r.Input, r.Part)
+		c := Check{Result: r, Expected: expected}
+		switch {
+		case r.Panic:
+			c.Status = Panic
+		case r.Timeout:
+			c.Status = Timeout
+		case r.Unsupported:
+			c.Status = Unsupported
+		case r.Error != "":
+			c.Status = Errored
+		case !ok:
+			c.Status = Unknown
+		case r.Answer == expected:
+			c.Status = Pass
+		default:
+			c.Status = Fail
+		}
+		checks[i] = c
+	}
+	return checks
+}
+
+// printChecks prints a table per day, with inputs in rows and parts side by side. It returns counts of statuses.
+func printChecks(w io.Writer, checks []Check, parts []int) map[Status]int {
+	counts := map[Status]int{}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprint(tw, "Day\tInput")
+	for _, part := range parts {
+		fmt.Fprintf(tw, "\tPart %d\t\tTime", part)
+	}
+	fmt.Fprintln(tw)
+	for i := 0; i < len(checks); {
+		day, input := checks[i].Day, checks[i].Input
+		fmt.Fprintf(tw, "%d\t%s", day, input)
+		for _, part := range parts {
+			j := slices.IndexFunc(checks[i:], func(c Check) bool {
+				return c.Day != day || c.Input != input || c.Part >= part
+			})
+			if j < 0 || checks[i+j].Day != day || checks[i+j].Input != input || checks[i+j].Part != part {
+				fmt.Fprint(tw, "\t\t\t") // no such part
+				continue
+			}
+			c := checks[i+j]
+			counts[c.Status]++
+			fmt.Fprintf(tw, "\t%s\t%s\t%v", cell(c), c.Status, c.Time.Round(time.Microsecond))
+		}
+		fmt.Fprintln(tw)
+		for i < len(checks) && checks[i].Day == day && checks[i].Input == input {
+			i++
+		}
+	}
+	tw.Flush()
+	return counts
+}
+
+// cell is the answer, or what went wrong.
+func cell(c Check) string {
+	switch c.Status {
+	case Fail:
+		return fmt.Sprintf("%s, want %s", c.Answer, c.Expected)
+	case Pass, Unknown:
+		return c.Answer
+	}
+	msg, _, _ := strings.Cut(c.Error, "\n")
+	const limit = 60
+	if len(msg) > limit {
+		msg = msg[:limit-3] + "..."
+	}
+	return msg
+}
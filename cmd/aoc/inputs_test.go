@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+)
+
+func TestCheckResults(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "01"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "01", "answers.json"),
+		[]byte(`{"input.txt": {"1": "11", "2": "31"}, "sample.txt": {"1": "3"}}`), 0644))
+	for _, tt := range []struct {
+		name     string
+		result   aoc.Result
+		expected string
+		status   Status
+	}{
+		{"pass", aoc.Result{Day: 1, Part: 1, Input: "input.txt", Answer: "11"}, "11", Pass},
+		{"mismatch", aoc.Result{Day: 1, Part: 2, Input: "input.txt", Answer: "32"}, "31", Fail},
+		{"missing answer", aoc.Result{Day: 1, Part: 2, Input: "sample.txt", Answer: "5"}, "", Unknown},
+		{"missing input", aoc.Result{Day: 1, Part: 1, Input: "input2.txt", Answer: "5"}, "", Unknown},
+		{"day without answers", aoc.Result{Day: 2, Part: 1, Input: "input.txt", Answer: "5"}, "", Unknown},
+		{"timed out", aoc.Result{Day: 1, Part: 1, Input: "input.txt", Error: "timeout after 1s", Timeout: true}, "11", Timeout},
+		{"timed out without answer", aoc.Result{Day: 1, Part: 2, Input: "sample.txt", Error: "timeout after 1s", Timeout: true}, "", Timeout},
+		{"panic", aoc.Result{Day: 1, Part: 1, Input: "input.txt", Error: "panic: boom", Panic: true}, "11", Panic},
+		{"unsupported", aoc.Result{Day: 1, Part: 1, Input: "sample.txt", Error: "input is not supported", Unsupported: true}, "3", Unsupported},
+		{"error", aoc.Result{Day: 1, Part: 1, Input: "input.txt", Error: "bad int"}, "11", Errored},
+	} {
+		checks := checkResults(root, []aoc.Result{tt.result})
+		require.Len(t, checks, 1, tt.name)
+		assert.Equal(t, Check{Result: tt.result, Expected: tt.expected, Status: tt.status}, checks[0], tt.name)
+	}
+}
+
+func TestPrintChecks(t *testing.T) {
+	check := func(day int, input string, part int, answer string, status Status, expected string) Check {
+		c := Check{Result: aoc.Result{Day: day, Part: part, Input: input, Answer: answer, Time: 1500400 * time.Nanosecond}, Status: status, Expected: expected}
+		if answer == "" {
+			c.Error = string(status) + " after 10s\nmore details"
+		}
+		return c
+	}
+	checks := []Check{
+		check(1, "input.txt", 1, "11", Pass, "11"),
+		check(1, "input.txt", 2, "32", Fail, "31"),
+		check(1, "input2.txt", 1, "5", Unknown, ""),
+		check(1, "input2.txt", 2, "", Timeout, ""),
+		check(25, "input.txt", 1, "3", Pass, "3"), // no part 2
+		check(25, "sample.txt", 1, "", Errored, ""),
+	}
+	var out strings.Builder
+	counts := printChecks(&out, checks, []int{1, 2})
+	assert.Equal(t, map[Status]int{Pass: 2, Fail: 1, Unknown: 1, Timeout: 1, Errored: 1}, counts)
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	assert.Equal(t, []string{
+		"Day Input Part 1 Time Part 2 Time",
+		"1 input.txt 11 ok 1.5ms 32, want 31 FAIL 1.5ms",
+		"1 input2.txt 5 ? 1.5ms timeout after 10s timeout 1.5ms",
+		"25 input.txt 3 ok 1.5ms",
+		"25 sample.txt ERROR after 10s ERROR 1.5ms",
+	}, lines)
+
+	// only part 2 asked for
+	out.Reset()
+	counts = printChecks(&out, []Check{check(1, "input.txt", 2, "32", Fail, "31")}, []int{2})
+	assert.Equal(t, map[Status]int{Fail: 1}, counts)
+	assert.Contains(t, out.String(), "32, want 31")
+}
+
+func TestCell(t *testing.T) {
+	long := strings.Repeat("x", 70)
+	for _, tt := range []struct {
+		check Check
+		want  string
+	}{
+		{Check{Result: aoc.Result{Answer: "11"}, Status: Pass}, "11"},
+		{Check{Result: aoc.Result{Answer: "11"}, Status: Unknown}, "11"},
+		{Check{Result: aoc.Result{Answer: "12"}, Expected: "11", Status: Fail}, "12, want 11"},
+		{Check{Result: aoc.Result{Error: "timeout after 1s"}, Status: Timeout}, "timeout after 1s"},
+		{Check{Result: aoc.Result{Error: "panic: boom\ngoroutine 1"}, Status: Panic}, "panic: boom"},
+		{Check{Result: aoc.Result{Error: long}, Status: Errored}, long[:57] + "..."},
+	} {
+		assert.Equal(t, tt.want, cell(tt.check))
+	}
+}
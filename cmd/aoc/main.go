@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string)
+}
+
+var commands = []command{
+	{"run", "run days and print answers", runCmd},
+	{"inputs", "run days on every input side by side, and check recorded answers", inputsCmd},
+	{"bench", "benchmark parts and compare with saved history", benchCmd},
+}
+
+func usage() {
+	fmt.Println("Usage: go run ./cmd/aoc <command> [flags]")
+	fmt.Println("Commands:")
+	for _, c := range commands {
+		fmt.Printf("  %-8s %s\n", c.name, c.usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+	for _, c := range commands {
+		if c.name == os.Args[1] {
+			c.run(os.Args[2:])
+			return
+		}
+	}
+	usage()
+	os.Exit(1)
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
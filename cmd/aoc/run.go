@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	_ "github.com/metalim/adventofcode.2024.go/aoc/all"
+)
+
+type runFlags struct {
+	root     string
+	days     string
+	part     int
+	input    string
+	parallel int
+	json     bool
+	verbose  bool
+	timeout  time.Duration
+}
+
+func (f *runFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.root, "root", ".", "repository root with day folders")
+	fs.StringVar(&f.days, "day", "all", "days to run, like 5, 5-12 or 1,3,20-")
+	fs.IntVar(&f.part, "part", 0, "part to run, 0 for both")
+	fs.StringVar(&f.input, "input", "input.txt", "input file name in the day folder")
+	fs.IntVar(&f.parallel, "parallel", 1, "number of days to run at once")
+	fs.BoolVar(&f.json, "json", false, "print results as JSON")
+	fs.BoolVar(&f.verbose, "v", false, "print what solvers log")
+	fs.DurationVar(&f.timeout, "timeout", 0, "time limit per part, 0 for none")
+}
+
+func runCmd(args []string) {
+	var f runFlags
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	f.register(fs)
+	fs.Parse(args)
+
+	days, err := aoc.ParseDays(f.days)
+	catch(err)
+	parts, err := aoc.ParseParts(f.part)
+	catch(err)
+	if !f.verbose {
+		aoc.Log = io.Discard
+	}
+
+	timeStart := time.Now()
+	results := runDays(days, f.parallel, func(p aoc.Puzzle) []aoc.Result {
+		return p.RunFile(context.Background(), f.root, f.input, f.timeout, parts...)
+	})
+	wall := time.Since(timeStart)
+
+	if f.json {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		catch(enc.Encode(results))
+		return
+	}
+	printResults(os.Stdout, results)
+	fmt.Printf("Total: %d results in %v\n", len(results), wall)
+}
+
+// runDays runs each day with up to parallel days at once. Parts of a day
+// always run one after another, as they share parsed input and package state.
+func runDays(days []int, parallel int, run func(aoc.Puzzle) []aoc.Result) []aoc.Result {
+	if parallel < 1 {
+		parallel = 1
+	}
+	perDay := make([][]aoc.Result, len(days))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, day := range days {
+		puzzle, _ := aoc.Get(day)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			perDay[i] = run(puzzle)
+		}()
+	}
+	wg.Wait()
+
+	var results []aoc.Result
+	for _, rs := range perDay {
+		results = append(results, rs...)
+	}
+	return results
+}
+
+func printResults(w io.Writer, results []aoc.Result) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tPart\tAnswer\tTime")
+	for _, r := range results {
+		answer := r.Answer
+		if r.Error != "" {
+			answer = "error: " + r.Error
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%v\n", r.Day, r.Part, answer, r.Time)
+	}
+	tw.Flush()
+}
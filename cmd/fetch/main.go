@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/site"
+	"github.com/metalim/adventofcode.2024.go/aoc/task"
+)
+
+var (
+	Root     string
+	Day      int
+	Force    bool
+	Cache    string
+	Interval time.Duration
+)
+
+// Fetch input and task of the day into NN/input.txt and NN/task.txt, with session cookie from AOC_SESSION
+func main() {
+	client := site.New()
+	flag.StringVar(&Root, "root", ".", "repository root with day folders")
+	flag.IntVar(&Day, "day", 0, "day to fetch")
+	flag.BoolVar(&Force, "f", false, "overwrite input.txt and task.txt, even if they are fine")
+	flag.StringVar(&Cache, "cache", client.CacheDir, "where downloads are kept, so they're never downloaded again")
+	flag.DurationVar(&Interval, "interval", client.Interval, "min time between requests to the site")
+	flag.Parse()
+	if Day < 1 || Day > 25 {
+		fmt.Println("Usage: go run ./cmd/fetch -day N")
+		os.Exit(1)
+	}
+	client.CacheDir = Cache
+	client.Interval = Interval
+	catch(fetch(os.Stdout, client, Root, Day, Force))
+}
+
+// what cmd/sanitize leaves of inputs and tasks
+var reSanitized = regexp.MustCompile(`<[\w\s\-]+ the content, and left this: \w+>`)
+
+func fetch(w io.Writer, client *site.Client, root string, day int, force bool) error {
+	dir := filepath.Join(root, aoc.Dir(day))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, "input.txt")
+	old, err := os.ReadFile(path)
+	if force || err != nil || reSanitized.Match(old) {
+		input, err := client.Input(day)
+		if err != nil {
+			return err
+		}
+		if err := save(w, path, input); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(w, "%s: already there\n", path)
+	}
+
+	// task grows part two after part one is solved
+	path = filepath.Join(dir, "task.txt")
+	old, err = os.ReadFile(path)
+	keep := !force && err == nil && !reSanitized.Match(old) // it's fine, unless part two is missing
+	if keep && bytes.Contains(old, []byte(task.PartTwo)) {
+		fmt.Fprintf(w, "%s: already there\n", path)
+		return nil
+	}
+	page, err := client.Page(day)
+	if err != nil {
+		return err
+	}
+	text, err := task.FromHTML(client.DayURL(day), page)
+	if err != nil {
+		return fmt.Errorf("%s: %w", client.DayURL(day), err)
+	}
+	if keep && !strings.Contains(text, task.PartTwo) {
+		fmt.Fprintf(w, "%s: no part two yet\n", path)
+		return nil
+	}
+	return save(w, path, []byte(text))
+}
+
+func save(w io.Writer, path string, content []byte) error {
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return err
+	}
+	lines := strings.Count(string(content), "\n")
+	fmt.Fprintf(w, "%s: saved, %d lines\n", path, lines)
+	return nil
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
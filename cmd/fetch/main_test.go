@@ -0,0 +1,189 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/site"
+)
+
+const partOne = `<article class="day-desc"><h2>--- Day 5: Print Queue ---</h2><p>Satisfied with their search on Ceres, the squadron of scholars suggests subsequently scanning the stationery stacks of sub-basement 17.</p>
+<p>For example:</p>
+<pre><code>47|53
+97|13
+
+75,47,61
+</code></pre>
+<p>The first update, <code>75,47,61</code>, is <em>in the right order</em>:</p>
+<ul>
+<li><code>75</code> is correctly first &amp; so on.</li>
+<li>61 is last.</li>
+</ul>
+<p>What do you get if you add up the <span title="Or the middle one.">middle</span> page numbers?</p>
+</article>
+<p>Your puzzle answer was <code>143</code>.</p>`
+
+const partTwo = `<article class="day-desc"><h2 id="part2">--- Part Two ---</h2><p>While the Elves get to work, you have a little time to fix the rest of them.</p>
+<p>What do you get if you add up the middle page numbers after correctly ordering just those updates?</p>
+</article>`
+
+const taskOne = `URL
+
+--- Day 5: Print Queue ---
+Satisfied with their search on Ceres, the squadron of scholars suggests subsequently scanning the stationery stacks of sub-basement 17.
+
+For example:
+
+47|53
+97|13
+
+75,47,61
+The first update, 75,47,61, is in the right order:
+
+75 is correctly first & so on.
+61 is last.
+What do you get if you add up the middle page numbers?
+`
+
+const taskTwo = taskOne + `
+
+--- Part Two ---
+While the Elves get to work, you have a little time to fix the rest of them.
+
+What do you get if you add up the middle page numbers after correctly ordering just those updates?
+`
+
+func page(articles ...string) string {
+	return "<!DOCTYPE html>\n<html><head><script>if (a < b && c) {}</script></head><body><main>\n" +
+		strings.Join(articles, "\n") + "\n</main></body></html>"
+}
+
+// fakeSite serves input and page of day 5, and counts requests.
+type fakeSite struct {
+	*httptest.Server
+	page     string
+	requests []string
+}
+
+func newFakeSite(t *testing.T) *fakeSite {
+	s := &fakeSite{page: page(partOne)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests = append(s.requests, r.URL.Path)
+		if c, err := r.Cookie("session"); err != nil || c.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, site.UserAgent, r.UserAgent())
+		switch r.URL.Path {
+		case "/2024/day/5/input":
+			io.WriteString(w, "47|53\n\n75,47,61\n")
+		case "/2024/day/5":
+			io.WriteString(w, s.page)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeSite) client(t *testing.T) *site.Client {
+	return &site.Client{URL: s.URL, Session: "secret", CacheDir: t.TempDir(), Interval: time.Hour, Sleep: func(time.Duration) {}}
+}
+
+func readTask(t *testing.T, root string, s *fakeSite) string {
+	bs, err := os.ReadFile(filepath.Join(root, "05", "task.txt"))
+	require.NoError(t, err)
+	return strings.Replace(string(bs), s.URL+"/2024/day/5", "URL", 1)
+}
+
+func TestFetch(t *testing.T) {
+	s := newFakeSite(t)
+	client := s.client(t)
+	root := t.TempDir()
+
+	require.NoError(t, fetch(io.Discard, client, root, 5, false))
+	input, err := os.ReadFile(filepath.Join(root, "05", "input.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "47|53\n\n75,47,61\n", string(input))
+	assert.Equal(t, taskOne, readTask(t, root, s))
+	assert.Equal(t, []string{"/2024/day/5/input", "/2024/day/5"}, s.requests)
+
+	// part two is not there yet, so only the page is fetched again
+	s.requests = nil
+	require.NoError(t, fetch(io.Discard, client, root, 5, false))
+	assert.Equal(t, []string{"/2024/day/5"}, s.requests)
+	assert.Equal(t, taskOne, readTask(t, root, s))
+
+	s.requests = nil
+	s.page = page(partOne, partTwo)
+	require.NoError(t, fetch(io.Discard, client, root, 5, false))
+	assert.Equal(t, []string{"/2024/day/5"}, s.requests)
+	assert.Equal(t, taskTwo, readTask(t, root, s))
+
+	// all done, nothing to fetch
+	s.requests = nil
+	require.NoError(t, fetch(io.Discard, client, root, 5, false))
+	assert.Empty(t, s.requests)
+}
+
+func TestFetchCache(t *testing.T) {
+	s := newFakeSite(t)
+	s.page = page(partOne, partTwo)
+	client := s.client(t)
+
+	require.NoError(t, fetch(io.Discard, client, t.TempDir(), 5, false))
+	assert.Len(t, s.requests, 2)
+
+	// another checkout, or sanitized one, gets files from the cache
+	s.requests = nil
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "05"), 0755))
+	sanitized := "<Lazy Lizard lost the content, and left this: 1234abcd>\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, "05", "input.txt"), []byte(sanitized), 0644))
+	require.NoError(t, fetch(io.Discard, client, root, 5, false))
+	assert.Empty(t, s.requests)
+	assert.Equal(t, taskTwo, readTask(t, root, s))
+	input, err := os.ReadFile(filepath.Join(root, "05", "input.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "47|53\n\n75,47,61\n", string(input))
+}
+
+func TestFetchInterval(t *testing.T) {
+	s := newFakeSite(t)
+	client := s.client(t)
+	var slept []time.Duration
+	client.Sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	require.NoError(t, fetch(io.Discard, client, t.TempDir(), 5, false))
+	require.Len(t, slept, 1, "no wait before the first request")
+	assert.Greater(t, slept[0], 59*time.Minute)
+}
+
+func TestFetchErrors(t *testing.T) {
+	s := newFakeSite(t)
+	client := s.client(t)
+
+	client.Session = ""
+	assert.ErrorIs(t, fetch(io.Discard, client, t.TempDir(), 5, false), site.ErrNoSession)
+
+	client.Session = "wrong"
+	err := fetch(io.Discard, client, t.TempDir(), 5, false)
+	assert.ErrorContains(t, err, "400 Bad Request: Puzzle inputs differ by user.")
+
+	// errors are not cached
+	client.Session = "secret"
+	s.requests = nil
+	assert.ErrorContains(t, fetch(io.Discard, client, t.TempDir(), 6, false), "404 Not Found")
+	assert.ErrorContains(t, fetch(io.Discard, client, t.TempDir(), 6, false), "404 Not Found")
+	assert.Len(t, s.requests, 2)
+}
@@ -1,13 +1,46 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	_ "github.com/metalim/adventofcode.2024.go/aoc/all"
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
+)
+
+var (
+	Timeout  time.Duration
+	Answers  bool
+	Prompt   string
+	Part     int
+	Language string
+	Model    string
 )
 
+// Make the next version folder in o1 of the day, with the prompt for v1, or feedback on the previous version.
+// vN.meta.json next to vN.txt says which prompt started the chat
 func main() {
+	flag.DurationVar(&Timeout, "timeout", 10*time.Second, "time limit per run of the previous version, and in the prompt")
+	flag.BoolVar(&Answers, "answers", true, "give expected answers in feedback, when answers are wrong")
+	flag.StringVar(&Prompt, "prompt", o1.DefaultPrompt, "prompt template in o1/prompts, like ru for the latest version, or ru.3")
+	flag.IntVar(&Part, "part", 0, "the only part to ask for, if the prompt has it")
+	flag.StringVar(&Language, "language", "Go", "language to ask for")
+	flag.StringVar(&Model, "model", "o1", "model the chat is with, to keep in vN.meta.json")
+	flag.Parse()
+
+	wd, err := os.Getwd()
+	catch(err)
+	day, err := strconv.Atoi(filepath.Base(wd))
+	if err != nil {
+		catch(fmt.Errorf("run makev in the day folder: %w", err))
+	}
+
 	version := 1
 	name := "v1"
 	for {
@@ -18,23 +51,107 @@ func main() {
 		name = "v" + strconv.Itoa(version)
 	}
 	folder := filepath.Join("o1", name)
-	err := os.MkdirAll(folder, 0755)
+	pathTxt := filepath.Join(folder, name+".txt")
+	err = os.MkdirAll(folder, 0755)
 	catch(err)
 	err = os.WriteFile(filepath.Join(folder, name+".go"), nil, 0644)
 	catch(err)
 	if version > 1 {
-		err = os.WriteFile(filepath.Join(folder, name+".txt"), nil, 0644)
+		feedback, err := feedback("..", day, version-1)
 		catch(err)
+		err = os.WriteFile(pathTxt, []byte(feedback), 0644)
+		catch(err)
+		fmt.Print(feedback)
+
+		// same chat, unless the model is changed
+		prev := fmt.Sprintf("v%d", version-1)
+		meta, err := o1.LoadMeta(o1.MetaPath(filepath.Join("o1", prev, prev+".txt")))
+		if os.IsNotExist(err) {
+			return
+		}
+		catch(err)
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "model" {
+				meta.Model = Model
+			}
+		})
+		catch(meta.Save(o1.MetaPath(pathTxt)))
 		return
 	}
-	prompt, err := os.ReadFile("../o1/prompt.txt")
+	vars := o1.PromptVars{Day: day, Part: Part, TimeLimit: Timeout, Language: Language, Model: Model}
+	prompt, meta, err := o1.Prompt("..", Prompt, vars)
 	catch(err)
-	prompt = append(prompt, "\n"...)
-	task, err := os.ReadFile("task.txt")
-	catch(err)
-	prompt = append(prompt, task...)
-	err = os.WriteFile(filepath.Join(folder, name+".txt"), prompt, 0644)
+	err = os.WriteFile(pathTxt, []byte(prompt), 0644)
 	catch(err)
+	catch(meta.Save(o1.MetaPath(pathTxt)))
+	fmt.Printf("%s: prompt %s.%d\n", pathTxt, meta.Prompt, meta.PromptVersion)
+}
+
+// feedback builds and runs the version on sample.txt and input.txt, and says what's wrong with it.
+// Answers come from the solver of the day, answers.json only saves running it again.
+func feedback(root string, day, version int) (string, error) {
+	if err := solve(root, day); err != nil {
+		return "", err
+	}
+	attempts, err := o1.Find(root, day)
+	if err != nil {
+		return "", err
+	}
+	for _, a := range attempts {
+		if a.Run != "" || a.Version != version {
+			continue
+		}
+		res := o1.Eval(context.Background(), root, a, o1.Options{
+			Timeout:      Timeout,
+			BuildTimeout: 2 * time.Minute,
+			Samples:      true,
+			Inputs:       []string{"sample.txt", "input.txt"},
+		})
+		return o1.Feedback(res, Answers), nil
+	}
+	return "", fmt.Errorf("no code of v%d in o1", version)
+}
+
+// solve runs the registered solver on sample.txt and input.txt, for parts answers.json has no answers for yet,
+// and records them. Inputs the solver doesn't support are left without answers.
+func solve(root string, day int) error {
+	puzzle, ok := aoc.Get(day)
+	if !ok {
+		return fmt.Errorf("day %d is not registered", day)
+	}
+	answers, err := aoc.LoadAnswers(root, day)
+	if err != nil {
+		return err
+	}
+	recorded := false
+	for _, input := range []string{"sample.txt", "input.txt"} {
+		if _, err := os.Stat(filepath.Join(root, aoc.Dir(day), input)); os.IsNotExist(err) {
+			continue
+		}
+		var parts []int
+		for _, part := range puzzle.Parts() {
+			if _, ok := answers.Get(input, part); !ok {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		for _, res := range puzzle.RunFile(context.Background(), root, input, 0, parts...) {
+			switch {
+			case res.Unsupported:
+			case res.Error != "":
+				return fmt.Errorf("day %d %s part %d: %s", day, input, res.Part, res.Error)
+			default:
+				answers.Set(input, res.Part, res.Answer)
+				recorded = true
+			}
+		}
+	}
+	if recorded {
+		return answers.Save(root, day)
+	}
+	return nil
 }
 
 func catch(err error) {
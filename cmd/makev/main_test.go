@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
+)
+
+// fake is day 99, answering 1 and 2 for any input.
+type fake struct{}
+
+func init() {
+	aoc.Register(99, fake{})
+}
+
+func (fake) Parse(input string) (string, error)                          { return input, nil }
+func (fake) Part1(ctx context.Context, input string) (aoc.Answer, error) { return aoc.Int(1), nil }
+func (fake) Part2(ctx context.Context, input string) (aoc.Answer, error) { return aoc.Int(2), nil }
+
+func program(body string) string {
+	return "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc main() {\n\tinput, _ := os.ReadFile(os.Args[1])\n\t_ = input\n" + body + "}\n"
+}
+
+func newRoot(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"o1/v1/v1.go":  program("\tunused := 1\n"),
+		"o1/v2/v2.go":  program("\tfmt.Println(\"Part 1:\", 1)\n\tfmt.Println(\"Part 2:\", 3)\n"),
+		"o1/v3/v3.go":  program("\tfmt.Println(\"Part 1:\", 1)\n\tfmt.Println(\"Part 2:\", 2)\n"),
+		"input.txt":    "1 2 3\n",
+		"input2.txt":   "1 2 3 4\n",
+		"sample.txt":   "1\n",
+		"answers.json": `{"input.txt": {"1": "1", "2": "2"}, "input2.txt": {"1": "5"}, "sample.txt": {"1": "1"}}`,
+	}
+	for name, text := range files {
+		path := filepath.Join(root, "99", filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(text), 0644))
+	}
+	return root
+}
+
+func TestFeedback(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds programs")
+	}
+	root := newRoot(t)
+	Timeout = 10 * time.Second
+	Answers = true
+
+	text, err := feedback(root, 99, 1)
+	require.NoError(t, err)
+	assert.Contains(t, text, "➜ go run ./o1/v1\n")
+	assert.Contains(t, text, "declared and not used: unused")
+
+	text, err = feedback(root, 99, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "➜ go run ./o1/v2 sample.txt\nPart 1: 1\nPart 2: 3\n\n"+
+		"Ответ на вторую часть неверный. Ожидаемый ответ: 2\n", text)
+
+	text, err = feedback(root, 99, 3)
+	require.NoError(t, err)
+	assert.Contains(t, text, "➜ go run ./o1/v3 input.txt\n")
+	assert.NotContains(t, text, "input2.txt", "only sample.txt and input.txt")
+	assert.Contains(t, text, "Ответ верный.")
+
+	_, err = feedback(root, 99, 4)
+	assert.EqualError(t, err, "no code of v4 in o1")
+
+	answers, err := aoc.LoadAnswers(root, 99)
+	require.NoError(t, err)
+	assert.Equal(t, aoc.Answers{"input.txt": {1: "1", 2: "2"}, "input2.txt": {1: "5"}, "sample.txt": {1: "1", 2: "2"}}, answers,
+		"the missing answer is recorded, other inputs are left alone")
+}
+
+func TestSolve(t *testing.T) {
+	root := newRoot(t)
+	require.NoError(t, os.Remove(filepath.Join(root, "99", "answers.json")))
+	require.NoError(t, solve(root, 99))
+	answers, err := aoc.LoadAnswers(root, 99)
+	require.NoError(t, err)
+	assert.Equal(t, aoc.Answers{"input.txt": {1: "1", 2: "2"}, "sample.txt": {1: "1", 2: "2"}}, answers)
+
+	// cached answers are not checked again
+	answers.Set("input.txt", 1, "7")
+	require.NoError(t, answers.Save(root, 99))
+	require.NoError(t, solve(root, 99))
+	answers, err = aoc.LoadAnswers(root, 99)
+	require.NoError(t, err)
+	assert.Equal(t, "7", answers["input.txt"][1])
+
+	assert.EqualError(t, solve(root, 98), "day 98 is not registered")
+}
+
+// templates make the prompts of the days they were used on, up to the task
+func TestPrompts(t *testing.T) {
+	for name, path := range map[string]string{
+		"ru.1": "01/o1/v1.txt",
+		"ru.2": "10/o1/v1.txt",
+		"ru.3": "13/o1/v1.txt",
+		"ru.4": "15/o1/run3/v1/v1.txt",
+		"ru":   "22/o1/v1/v1.txt",
+	} {
+		want, err := os.ReadFile(filepath.Join("../..", path))
+		require.NoError(t, err)
+		day, err := strconv.Atoi(path[:2])
+		require.NoError(t, err)
+		vars := o1.PromptVars{Day: day, TimeLimit: 10 * time.Second, Language: "Go", Model: "o1"}
+		got, meta, err := o1.Prompt("../..", name, vars)
+		require.NoError(t, err)
+		before, _, ok := strings.Cut(string(want), "https://")
+		require.True(t, ok, path)
+		assert.True(t, strings.HasPrefix(got, before), "%s\n%s", name, got[:min(len(got), len(before))])
+		assert.Equal(t, "ru", meta.Prompt)
+	}
+
+	got, meta, err := o1.Prompt("../..", "ru", o1.PromptVars{Day: 22, Part: 2, TimeLimit: 5 * time.Second, Language: "Rust"})
+	require.NoError(t, err)
+	assert.Contains(t, got, "Реши только вторую часть задачи.\n")
+	assert.Contains(t, got, "не превышает 5 секунд")
+	assert.Contains(t, got, "синтаксическая ошибка в Rust")
+	assert.Equal(t, 5, meta.PromptVersion)
+
+	_, _, err = o1.Prompt("../..", "nope", o1.PromptVars{Day: 22})
+	assert.EqualError(t, err, `no prompt "nope" in o1/prompts`)
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/task"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Kinds of input, to pick the parser of the new day.
+var Kinds = []string{"lines", "grid", "sections", "ints"}
+
+var (
+	Root string
+	Day  int
+	Kind string
+	Task string
+)
+
+// Scaffold a new day from the 99 template, with task.txt and its examples as sample files
+func main() {
+	flag.StringVar(&Root, "root", ".", "repository root with day folders")
+	flag.IntVar(&Day, "day", 0, "day to create, 1 to 25")
+	flag.StringVar(&Kind, "kind", "lines", "kind of input: "+strings.Join(Kinds, ", "))
+	flag.StringVar(&Task, "task", "", "file with the puzzle text to save as task.txt, - for stdin")
+	flag.Parse()
+	if Day < 1 || Day > 25 || flag.NArg() > 0 || !slices.Contains(Kinds, Kind) {
+		fmt.Println("Usage: go run ./cmd/newday -day 1..25 [-kind lines|grid|sections|ints] [-task FILE|-]")
+		os.Exit(1)
+	}
+
+	var text []byte
+	var err error
+	switch Task {
+	case "":
+	case "-":
+		text, err = io.ReadAll(os.Stdin)
+	default:
+		text, err = os.ReadFile(Task)
+	}
+	catch(err)
+	catch(newDay(os.Stdout, Root, Day, Kind, string(text)))
+}
+
+// Params of templates.
+type Params struct {
+	Day int
+	Dir string // like "05"
+	Pkg string // like "day05"
+}
+
+// newDay writes the day folder, registers the day in aoc/all, and saves the task and its examples.
+// Task text may be empty, then task.txt of the day is used, if cmd/fetch got it already.
+func newDay(w io.Writer, root string, day int, kind, text string) error {
+	p := Params{Day: day, Dir: aoc.Dir(day), Pkg: "day" + aoc.Dir(day)}
+	dir := filepath.Join(root, p.Dir)
+	if _, err := os.Stat(filepath.Join(dir, p.Pkg)); err == nil {
+		return fmt.Errorf("%s already exists", filepath.Join(dir, p.Pkg))
+	}
+	if err := os.MkdirAll(filepath.Join(dir, p.Pkg), 0755); err != nil {
+		return err
+	}
+	files := []struct{ tmpl, path string }{
+		{"main.go.tmpl", filepath.Join(dir, "main.go")},
+		{kind + ".go.tmpl", filepath.Join(dir, p.Pkg, p.Pkg+".go")},
+		{"bench_test.go.tmpl", filepath.Join(dir, p.Pkg, "bench_test.go")},
+	}
+	for _, f := range files {
+		src, err := render(f.tmpl, p)
+		if err != nil {
+			return err
+		}
+		if err := writeNew(w, f.path, src); err != nil {
+			return err
+		}
+	}
+	if err := register(w, root, p); err != nil {
+		return err
+	}
+
+	taskFile := filepath.Join(dir, "task.txt")
+	if text != "" {
+		if err := os.WriteFile(taskFile, []byte(text), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "wrote", taskFile)
+	} else {
+		bs, err := os.ReadFile(taskFile)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		text = string(bs)
+	}
+	if err := writeSamples(w, dir, text); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "input.txt")); os.IsNotExist(err) {
+		fmt.Fprintf(w, "get the input with: go run ./cmd/fetch -day %d\n", day)
+	}
+	fmt.Fprintf(w, "run with: cd %s && go run . sample.txt\n", p.Dir)
+	return nil
+}
+
+// render executes the template, and formats the result as Go source.
+func render(name string, p Params) ([]byte, error) {
+	t, err := template.ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, p); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return src, nil
+}
+
+// writeNew writes the file, unless it exists already.
+func writeNew(w io.Writer, path string, data []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		fmt.Fprintln(w, "kept", path)
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintln(w, "wrote", path)
+	return nil
+}
+
+// register adds import of the day to aoc/all, keeping imports sorted.
+func register(w io.Writer, root string, p Params) error {
+	path := filepath.Join(root, "aoc", "all", "all.go")
+	bs, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil // not a full repository, nothing to register in
+	}
+	if err != nil {
+		return err
+	}
+	line := fmt.Sprintf("\t_ %q\n", "github.com/metalim/adventofcode.2024.go/"+p.Dir+"/"+p.Pkg)
+	src := string(bs)
+	if strings.Contains(src, line) {
+		return nil
+	}
+	start := strings.Index(src, "import (\n")
+	end := strings.Index(src, "\n)")
+	if start < 0 || end < start {
+		return fmt.Errorf("%s: no import block", path)
+	}
+	start += len("import (\n")
+	lines := strings.SplitAfter(src[start:end+1], "\n")
+	lines = slices.DeleteFunc(lines, func(l string) bool { return l == "" })
+	i, _ := slices.BinarySearch(lines, line)
+	lines = slices.Insert(lines, i, line)
+	src = src[:start] + strings.Join(lines, "") + src[end+1:]
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		return err
+	}
+	fmt.Fprintln(w, "registered in", path)
+	return nil
+}
+
+// writeSamples saves example inputs of the task as sample.txt, sample2.txt, and so on.
+// Examples already saved are skipped, and so are repeats of the same example.
+func writeSamples(w io.Writer, dir, text string) error {
+	if text == "" {
+		return nil
+	}
+	like, err := os.ReadFile(filepath.Join(dir, "input.txt"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	seen := map[string]bool{} // inputs of existing samples
+	i := 1
+	for ; ; i++ {
+		bs, err := os.ReadFile(filepath.Join(dir, sampleName(i)))
+		if os.IsNotExist(err) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		seen[strings.TrimSpace(string(bs))] = true
+	}
+	if len(like) == 0 {
+		fmt.Fprintln(w, "no input.txt to compare examples with, so every block of the task is taken, check them")
+	}
+	// url and title of the task are not examples
+	if i := strings.Index(text, "---\n"); i >= 0 && strings.Contains(text[:i], "--- Day ") {
+		text = text[i+len("---\n"):]
+	}
+	for _, ex := range task.Examples(text, string(like)) {
+		key := strings.TrimSpace(ex.Input)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		path := filepath.Join(dir, sampleName(i))
+		i++
+		if err := os.WriteFile(path, []byte(ex.Input), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "wrote %s, %d lines", path, strings.Count(key, "\n")+1)
+		for _, part := range []int{1, 2} {
+			if answer, ok := ex.Answers[part]; ok {
+				fmt.Fprintf(w, ", part %d: %s", part, answer)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// sampleName is sample.txt, then sample2.txt, and so on.
+func sampleName(i int) string {
+	if i == 1 {
+		return "sample.txt"
+	}
+	return fmt.Sprintf("sample%d.txt", i)
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
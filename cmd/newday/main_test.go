@@ -0,0 +1,96 @@
+package main
+
+import (
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 99 is the template, and lines kind should give the same.
+func TestTemplate99(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, newDay(io.Discard, root, 99, "lines", ""))
+	for _, file := range []string{"main.go", "day99/day99.go"} {
+		want, err := os.ReadFile(filepath.Join("../../99", file))
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join(root, "99", file))
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), file)
+	}
+}
+
+// Other kinds are 99 too, besides parsing and what parts do.
+func TestKinds(t *testing.T) {
+	want := skeleton(t, "../../99/day99/day99.go")
+	for _, kind := range Kinds {
+		root := t.TempDir()
+		require.NoError(t, newDay(io.Discard, root, 99, kind, ""))
+		assert.Equal(t, want, skeleton(t, filepath.Join(root, "99", "day99", "day99.go")), kind)
+	}
+}
+
+// skeleton is declarations of the day, without imports, Parsed and Parse, and with bodies of parts left out.
+func skeleton(t *testing.T, path string) []string {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, 0)
+	require.NoError(t, err)
+	var decls []string
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT || d.Tok == token.TYPE && d.Specs[0].(*ast.TypeSpec).Name.Name == "Parsed" {
+				continue
+			}
+		case *ast.FuncDecl:
+			if d.Name.Name == "Parse" {
+				continue
+			}
+			if d.Recv != nil {
+				d.Body = nil
+			}
+		}
+		var b strings.Builder
+		require.NoError(t, format.Node(&b, fset, decl))
+		decls = append(decls, b.String())
+	}
+	return decls
+}
+
+func TestNewDay(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "aoc", "all"), 0755))
+	all := "package all\n\nimport (\n\t_ \"github.com/metalim/adventofcode.2024.go/01/day01\"\n\t_ \"github.com/metalim/adventofcode.2024.go/09/day09\"\n)\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, "aoc", "all", "all.go"), []byte(all), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "05"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "05", "input.txt"), []byte("1|2\n3|4\n\n1,2,3\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "05", "sample.txt"), []byte("1|2\n\n1,2\n"), 0644))
+	text := "https://adventofcode.com/2024/day/5\n\n--- Day 5: Test ---\nFor example:\n\n47|53\n97|13\n\n75,47,61\n97,61,53\n\nIn the above example, the answer is 143.\n\n" +
+		"Now the same example again:\n\n47|53\n97|13\n\n75,47,61\n97,61,53\n\nAnd a bigger one:\n\n1|2\n\n3,4\n5,6\n"
+
+	require.NoError(t, newDay(io.Discard, root, 5, "sections", text))
+	for _, file := range []string{"main.go", "day05/day05.go", "day05/bench_test.go", "task.txt"} {
+		assert.FileExists(t, filepath.Join(root, "05", file))
+	}
+	bs, err := os.ReadFile(filepath.Join(root, "05", "sample2.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "47|53\n97|13\n\n75,47,61\n97,61,53\n", string(bs), "sample.txt is taken already")
+	bs, err = os.ReadFile(filepath.Join(root, "05", "sample3.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1|2\n\n3,4\n5,6\n", string(bs), "repeated example is skipped")
+	assert.NoFileExists(t, filepath.Join(root, "05", "sample4.txt"))
+
+	bs, err = os.ReadFile(filepath.Join(root, "aoc", "all", "all.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(bs), "day01\"\n\t_ \"github.com/metalim/adventofcode.2024.go/05/day05\"\n\t_ \"github.com/metalim/adventofcode.2024.go/09/day09\"\n)")
+
+	assert.ErrorContains(t, newDay(io.Discard, root, 5, "lines", ""), "already exists")
+}
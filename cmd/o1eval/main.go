@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	_ "github.com/metalim/adventofcode.2024.go/aoc/all"
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
+)
+
+var (
+	Root         string
+	Days         string
+	Out          string
+	Timeout      time.Duration
+	BuildTimeout time.Duration
+	Samples      bool
+	Parallel     int
+	Verbose      bool
+)
+
+// Build and run each o1 attempt on inputs with recorded answers, and classify it
+func main() {
+	flag.StringVar(&Root, "root", ".", "repository root with day folders")
+	flag.StringVar(&Days, "day", "all", "days to evaluate, like 5, 5-12 or 1,3,20-")
+	flag.StringVar(&Out, "o", "o1/eval.json", "report file, relative to root unless absolute, - for stdout")
+	flag.DurationVar(&Timeout, "timeout", 10*time.Second, "time limit per run, the prompt asks for 10s")
+	flag.DurationVar(&BuildTimeout, "build-timeout", 2*time.Minute, "time limit to compile")
+	flag.BoolVar(&Samples, "samples", true, "run sample files too, not just inputs")
+	flag.IntVar(&Parallel, "parallel", 1, "number of attempts to evaluate at once, more makes timing less fair")
+	flag.BoolVar(&Verbose, "v", false, "print each run too")
+	flag.Parse()
+
+	days, err := aoc.ParseDays(Days)
+	catch(err)
+	var attempts []o1.Attempt
+	for _, day := range days {
+		as, err := o1.Find(Root, day)
+		catch(err)
+		attempts = append(attempts, as...)
+	}
+
+	opts := o1.Options{Timeout: Timeout, BuildTimeout: BuildTimeout, Samples: Samples}
+	results := make([]o1.Result, len(attempts))
+	sem := make(chan struct{}, max(Parallel, 1))
+	var wg sync.WaitGroup
+	for i, a := range attempts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = o1.Eval(context.Background(), Root, a, opts)
+		}()
+	}
+	wg.Wait()
+
+	printResults(results)
+	report := o1.Report{Time: time.Now().UTC().Truncate(time.Second), Go: runtime.Version(), Timeout: Timeout, Samples: Samples, Attempts: results}
+	catch(writeReport(report))
+}
+
+func printResults(results []o1.Result) {
+	counts := map[o1.Outcome]int{}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tRun\tVersion\tLabel\tOutcome\tPart 1\tPart 2\tFindings")
+	for _, r := range results {
+		counts[r.Outcome.Kind()]++
+		fmt.Fprintf(tw, "%d\t%s\tv%d\t%s\t%s\t%s\t%s\t%s\n", r.Day, r.Run, r.Version, r.Label, r.Outcome, r.Parts[1], r.Parts[2], kinds(r.Findings))
+		if !Verbose {
+			continue
+		}
+		for _, f := range r.Findings {
+			fmt.Fprintf(tw, "\t\t\t\t%s\n", f)
+		}
+		if r.Build != "" {
+			first, _, _ := strings.Cut(r.Build, "\n")
+			fmt.Fprintf(tw, "\t\t\t\t%s\n", first)
+		}
+		for _, run := range r.Runs {
+			fmt.Fprintf(tw, "\t\t\t%s\t%s\t%s\t%s\t%v\n", run.Input, run.Outcome, run.Parts[1], run.Parts[2], run.Time.Round(time.Millisecond))
+		}
+	}
+	tw.Flush()
+	var summary []string
+	for _, o := range append(o1.Outcomes, o1.Unchecked) {
+		if counts[o] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[o], o))
+		}
+	}
+	fmt.Printf("%d attempts: %s\n", len(results), strings.Join(summary, ", "))
+}
+
+// kinds of findings, like "stub, hardcoded answer".
+func kinds(findings []o1.Finding) string {
+	var ks []string
+	for _, f := range findings {
+		if !slices.Contains(ks, string(f.Kind)) {
+			ks = append(ks, string(f.Kind))
+		}
+	}
+	return strings.Join(ks, ", ")
+}
+
+func writeReport(report o1.Report) error {
+	if Out == "-" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	path := Out
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(Root, path)
+	}
+	if err := report.Save(path); err != nil {
+		return err
+	}
+	fmt.Println("Report:", path)
+	return nil
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
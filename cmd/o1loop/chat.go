@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Message of the chat.
+type Message struct {
+	Role    string `json:"role"` // user or assistant
+	Content string `json:"content"`
+}
+
+// Chat is a client of OpenAI-compatible chat completions API.
+type Chat struct {
+	URL   string // base, like https://api.openai.com/v1
+	Key   string
+	Model string
+	HTTP  *http.Client
+}
+
+// Complete returns the reply of the model to the messages.
+func (c *Chat) Complete(ctx context.Context, messages []Message) (string, error) {
+	body, err := json.Marshal(map[string]any{"model": c.Model, "messages": messages})
+	if err != nil {
+		return "", err
+	}
+	url := strings.TrimSuffix(c.URL, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Key != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Key)
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		first, _, _ := strings.Cut(strings.TrimSpace(string(bs)), "\n")
+		return "", fmt.Errorf("POST %s: %s: %s", url, resp.Status, first)
+	}
+	var reply struct {
+		Choices []struct {
+			Message Message `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(bs, &reply); err != nil {
+		return "", fmt.Errorf("POST %s: %w", url, err)
+	}
+	if len(reply.Choices) == 0 {
+		return "", errors.New("no choices in the reply")
+	}
+	return reply.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
+)
+
+var (
+	Root       string
+	Day        int
+	Run        string
+	Prompt     string
+	N          int
+	Timeout    time.Duration
+	APITimeout time.Duration
+	Answers    bool
+)
+
+// Let the model solve the day: send the prompt, run the code it gives, and send back what's wrong, up to N times
+func main() {
+	chat := &Chat{URL: os.Getenv("OPENAI_BASE_URL"), Key: os.Getenv("OPENAI_API_KEY")}
+	if chat.URL == "" {
+		chat.URL = "https://api.openai.com/v1"
+	}
+	flag.StringVar(&Root, "root", ".", "repository root with day folders")
+	flag.IntVar(&Day, "day", 0, "day to solve")
+	flag.StringVar(&Run, "run", "", "run folder in NN/o1, like run2 or run3-o1-mini, if the day has attempts already")
+	flag.StringVar(&Prompt, "prompt", o1.DefaultPrompt, "prompt template in o1/prompts, like ru for the latest version, or ru.3")
+	flag.IntVar(&N, "n", 10, "max number of attempts")
+	flag.StringVar(&chat.URL, "url", chat.URL, "base URL of OpenAI-compatible API, $OPENAI_BASE_URL by default")
+	flag.StringVar(&chat.Model, "model", "o1", "model name")
+	flag.DurationVar(&Timeout, "timeout", 10*time.Second, "time limit per run of the code")
+	flag.DurationVar(&APITimeout, "api-timeout", 10*time.Minute, "time limit for a reply of the model")
+	flag.BoolVar(&Answers, "answers", true, "tell the model expected answers, when its answers are wrong")
+	flag.Parse()
+	if Day < 1 || flag.NArg() > 0 {
+		fmt.Println("Usage: go run ./cmd/o1loop -day N [-run NAME] [-model o1] [-n 10]")
+		os.Exit(1)
+	}
+	chat.HTTP = &http.Client{Timeout: APITimeout}
+
+	solved, err := loop(context.Background(), os.Stdout, chat, Root, Day, Run, Prompt, N, Answers)
+	catch(err)
+	if !solved {
+		os.Exit(1)
+	}
+}
+
+// loop writes each message to vN/vN.txt, and each program of the model to vN/vN.go, in NN/o1 or in the run folder.
+// Full replies go to vN/vN.md, and the prompt of the chat to vN/vN.meta.json. It stops when all answers are correct,
+// and reports if they were. Without answers to check the code on, it doesn't start.
+func loop(ctx context.Context, w io.Writer, chat *Chat, root string, day int, run, promptName string, n int, answers bool) (bool, error) {
+	dir := filepath.Join(root, aoc.Dir(day), "o1", run)
+	existing, err := o1.Find(root, day)
+	if err != nil {
+		return false, err
+	}
+	for _, a := range existing {
+		if a.Run == run {
+			return false, fmt.Errorf("%s has attempts already, start another run with -run", dir)
+		}
+	}
+	vars := o1.PromptVars{Day: day, Part: o1.Attempt{Run: run}.Part(), TimeLimit: Timeout, Language: "Go", Model: chat.Model}
+	known, err := aoc.LoadAnswers(root, day)
+	if err != nil {
+		return false, err
+	}
+	if !checkable(known, vars.Part) {
+		return false, fmt.Errorf("no answers for sample.txt or input.txt in %s", filepath.Join(root, aoc.Dir(day), aoc.AnswersFile))
+	}
+	prompt, meta, err := o1.Prompt(root, promptName, vars)
+	if err != nil {
+		return false, err
+	}
+
+	messages := []Message{{Role: "user", Content: prompt}}
+	defer func() {
+		if last := messages[len(messages)-1]; last.Role == "user" && len(messages) > 1 {
+			fmt.Fprintf(w, "Last feedback, not sent:\n%s", last.Content)
+		}
+	}()
+	opts := o1.Options{Timeout: Timeout, BuildTimeout: 2 * time.Minute, Samples: true}
+	for version := 1; version <= n; version++ {
+		name := fmt.Sprintf("v%d", version)
+		vdir := filepath.Join(dir, name)
+		if err := os.MkdirAll(vdir, 0755); err != nil {
+			return false, err
+		}
+		if err := os.WriteFile(filepath.Join(vdir, name+".txt"), []byte(messages[len(messages)-1].Content), 0644); err != nil {
+			return false, err
+		}
+		if err := meta.Save(o1.MetaPath(filepath.Join(vdir, name+".txt"))); err != nil {
+			return false, err
+		}
+		fmt.Fprintf(w, "%s: asking %s\n", name, chat.Model)
+		reply, err := chat.Complete(ctx, messages)
+		if err != nil {
+			return false, err
+		}
+		if err := os.WriteFile(filepath.Join(vdir, name+".md"), []byte(reply), 0644); err != nil {
+			return false, err
+		}
+		messages = append(messages, Message{Role: "assistant", Content: reply})
+
+		code, ok := o1.ExtractGo(reply)
+		if !ok {
+			fmt.Fprintf(w, "%s: no code\n", name)
+			messages = append(messages, Message{Role: "user", Content: "В ответе нет кода. Выдай полное решение на Go одним блоком кода.\n"})
+			continue
+		}
+		file := filepath.Join(vdir, name+".go")
+		if err := os.WriteFile(file, []byte(code), 0644); err != nil {
+			return false, err
+		}
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return false, err
+		}
+		a := o1.Attempt{Day: day, Run: run, Version: version, Path: filepath.ToSlash(rel)}
+		res := o1.Eval(ctx, root, a, opts)
+		fmt.Fprintf(w, "%s: %s\n", name, res.Outcome)
+		switch res.Outcome {
+		case o1.Correct:
+			messages = messages[:1] // nothing to report
+			return true, nil
+		case o1.Unchecked:
+			messages = messages[:1]
+			return false, fmt.Errorf("%s: no answers to check it on", name)
+		}
+		feedback := o1.Feedback(res, answers)
+		if feedback == "" {
+			messages = messages[:1]
+			return false, fmt.Errorf("%s: %s, with nothing to tell the model: %s", name, res.Outcome, res.Build)
+		}
+		messages = append(messages, Message{Role: "user", Content: feedback})
+	}
+	return false, nil
+}
+
+// checkable tells if there are answers for the part (or any, if 0) of sample.txt or input.txt.
+func checkable(answers aoc.Answers, part int) bool {
+	for _, input := range []string{"sample.txt", "input.txt"} {
+		for _, p := range []int{1, 2} {
+			if _, ok := answers.Get(input, p); ok && (part == 0 || part == p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
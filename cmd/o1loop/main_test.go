@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
+)
+
+// fakeModel replies with the next of replies, and keeps requests. It calls onRequest first, if set.
+type fakeModel struct {
+	*httptest.Server
+	replies   []string
+	requests  [][]Message
+	onRequest func()
+}
+
+func newFakeModel(t *testing.T, replies ...string) *fakeModel {
+	m := &fakeModel{replies: replies}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, `{"error": "nope"}`, http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			Model    string    `json:"model"`
+			Messages []Message `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "o1-fake", req.Model)
+		m.requests = append(m.requests, req.Messages)
+		if m.onRequest != nil {
+			m.onRequest()
+		}
+		reply := m.replies[0]
+		m.replies = m.replies[1:]
+		json.NewEncoder(w).Encode(map[string]any{
+			"choices": []any{map[string]any{"message": Message{Role: "assistant", Content: reply}}},
+		})
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+func (m *fakeModel) chat() *Chat {
+	return &Chat{URL: m.URL + "/v1", Key: "secret", Model: "o1-fake"}
+}
+
+func reply(body string) string {
+	return fmt.Sprintf("Here you go:\n\n```go\npackage main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc main() {\n\t_, _ = os.ReadFile(os.Args[1])\n%s}\n```\n\nIt prints both parts.", body)
+}
+
+func newRoot(t *testing.T) string {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"o1/prompts/test.1.tmpl": "Solve it.\n",
+		"o1/prompts/test.2.tmpl": "Solve it in {{.Language}}{{if .Part}}, part {{.Part}} only{{end}}.\n",
+		"99/task.txt":            "--- Day 99: Test ---\n",
+		"99/input.txt":           "1 2 3\n",
+		"99/sample.txt":          "1\n",
+		"99/answers.json":        `{"input.txt": {"1": "6", "2": "3"}, "sample.txt": {"1": "1", "2": "1"}}`,
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return root
+}
+
+// sums prints the sum of numbers, and how many there are
+const sums = "\tbs, _ := os.ReadFile(os.Args[1])\n\tvar n, sum int\n\tfor _, c := range string(bs) {\n\t\tif c >= '0' && c <= '9' {\n\t\t\tn++\n\t\t\tsum += int(c - '0')\n\t\t}\n\t}\n\tfmt.Println(\"Part 1:\", sum)\n\tfmt.Println(\"Part 2:\", %s)\n"
+
+func TestLoop(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds programs")
+	}
+	root := newRoot(t)
+	m := newFakeModel(t,
+		"I can't.",
+		reply("\tunused := 1\n"),
+		reply(fmt.Sprintf(sums, "n+1")),
+		reply(fmt.Sprintf(sums, "n")),
+	)
+	var out strings.Builder
+	solved, err := loop(context.Background(), &out, m.chat(), root, 99, "", "test", 10, true)
+	require.NoError(t, err)
+	assert.True(t, solved, out.String())
+	require.Len(t, m.requests, 4)
+	assert.Equal(t, "v1: asking o1-fake\nv1: no code\nv2: asking o1-fake\nv2: compile error\nv3: asking o1-fake\nv3: incorrect p2\nv4: asking o1-fake\nv4: correct\n", out.String())
+
+	assert.Equal(t, []Message{{"user", "Solve it in Go.\n\n--- Day 99: Test ---\n"}}, m.requests[0])
+	meta, err := o1.LoadMeta(filepath.Join(root, "99", "o1", "v3", "v3.meta.json"))
+	require.NoError(t, err)
+	assert.Equal(t, o1.Meta{Prompt: "test", PromptVersion: 2,
+		PromptVars: o1.PromptVars{Day: 99, TimeLimit: Timeout, Language: "Go", Model: "o1-fake"}}, meta)
+	last := m.requests[3]
+	require.Len(t, last, 7)
+	assert.Equal(t, "➜ go run ./o1/v2\no1/v2/v2.go:4:2: \"fmt\" imported and not used\no1/v2/v2.go:10:2: declared and not used: unused\n", last[4].Content)
+	assert.Equal(t, "➜ go run ./o1/v3 sample.txt\nPart 1: 1\nPart 2: 2\n\nОтвет на вторую часть неверный. Ожидаемый ответ: 1\n", last[6].Content)
+
+	for _, file := range []string{"v1/v1.txt", "v1/v1.md", "v2/v2.go", "v3/v3.txt", "v4/v4.go", "v4/v4.md"} {
+		assert.FileExists(t, filepath.Join(root, "99", "o1", filepath.FromSlash(file)))
+	}
+	assert.NoFileExists(t, filepath.Join(root, "99", "o1", "v1", "v1.go"))
+	bs, err := os.ReadFile(filepath.Join(root, "99", "o1", "v3", "v3.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, last[4].Content, string(bs), "vN.txt is the message that asked for vN")
+
+	_, err = loop(context.Background(), io.Discard, m.chat(), root, 99, "", "test", 10, true)
+	assert.ErrorContains(t, err, "has attempts already")
+}
+
+func TestLoopGivesUp(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds programs")
+	}
+	root := newRoot(t)
+	m := newFakeModel(t, reply(fmt.Sprintf(sums, "0")), reply(fmt.Sprintf(sums, "0")))
+	var out strings.Builder
+	solved, err := loop(context.Background(), &out, m.chat(), root, 99, "run2-o1-fake-p2", "test.1", 2, false)
+	require.NoError(t, err)
+	assert.False(t, solved)
+	assert.Contains(t, out.String(), "v2: incorrect\nLast feedback, not sent:\n➜ go run ./o1/run2-o1-fake-p2/v2 sample.txt\n")
+	assert.True(t, strings.HasSuffix(out.String(), "Ответ на вторую часть неверный.\n"), "without answers")
+	assert.FileExists(t, filepath.Join(root, "99", "o1", "run2-o1-fake-p2", "v2", "v2.go"))
+	assert.Equal(t, "Solve it.\n\n--- Day 99: Test ---\n", m.requests[0][0].Content, "pinned version")
+	meta, err := o1.LoadMeta(filepath.Join(root, "99", "o1", "run2-o1-fake-p2", "v2", "v2.meta.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "test", meta.Prompt)
+	assert.Equal(t, 1, meta.PromptVersion)
+	assert.Equal(t, 2, meta.Part)
+}
+
+func TestLoopNeedsAnswers(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		answers string
+		run     string
+	}{
+		{"no answers", "", ""},
+		{"other inputs only", `{"input2.txt": {"1": "6"}}`, ""},
+		{"other part only", `{"input.txt": {"1": "6"}}`, "run2-p2"},
+	} {
+		root := newRoot(t)
+		path := filepath.Join(root, "99", "answers.json")
+		if tt.answers == "" {
+			require.NoError(t, os.Remove(path))
+		} else {
+			require.NoError(t, os.WriteFile(path, []byte(tt.answers), 0644))
+		}
+		m := newFakeModel(t)
+		_, err := loop(context.Background(), io.Discard, m.chat(), root, 99, tt.run, "test", 10, true)
+		assert.ErrorContains(t, err, "no answers for sample.txt or input.txt in ", tt.name)
+		assert.Empty(t, m.requests, tt.name)
+		assert.NoDirExists(t, filepath.Join(root, "99", "o1"), tt.name)
+	}
+}
+
+func TestLoopUnchecked(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds programs")
+	}
+	root := newRoot(t)
+	m := newFakeModel(t, reply(fmt.Sprintf(sums, "n")))
+	m.onRequest = func() { // answers are gone while the model thinks
+		require.NoError(t, os.Remove(filepath.Join(root, "99", "answers.json")))
+	}
+	var out strings.Builder
+	solved, err := loop(context.Background(), &out, m.chat(), root, 99, "", "test", 10, true)
+	assert.EqualError(t, err, "v1: no answers to check it on")
+	assert.False(t, solved)
+	assert.Equal(t, "v1: asking o1-fake\nv1: unchecked\n", out.String(), "no feedback, sent or not")
+	require.Len(t, m.requests, 1)
+}
+
+func TestChatError(t *testing.T) {
+	m := newFakeModel(t)
+	chat := m.chat()
+	chat.Key = "wrong"
+	_, err := chat.Complete(context.Background(), []Message{{"user", "hi"}})
+	assert.ErrorContains(t, err, "401 Unauthorized: {\"error\": \"nope\"}")
+}
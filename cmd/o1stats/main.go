@@ -0,0 +1,291 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	_ "github.com/metalim/adventofcode.2024.go/aoc/all"
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
+)
+
+var (
+	Root    string
+	Days    string
+	Report  string
+	Timeout time.Duration
+	Out     string
+	SVG     string
+)
+
+// Make a dataset of o1 attempts, one record per part, and print success rates by model and prompt
+func main() {
+	flag.StringVar(&Root, "root", ".", "repository root with day folders")
+	flag.StringVar(&Days, "day", "all", "days to include, like 5, 5-12 or 1,3,20-")
+	flag.StringVar(&Report, "report", "o1/eval.json", "o1eval report, relative to root unless absolute. Attempts missing in it are evaluated")
+	flag.DurationVar(&Timeout, "timeout", 10*time.Second, "time limit per run, for attempts missing in the report")
+	flag.StringVar(&Out, "o", "o1/stats.csv", "dataset file, .csv or .json, relative to root unless absolute, - for CSV to stdout, empty for none")
+	flag.StringVar(&SVG, "svg", "", "folder to write outcomes.svg and prompts.svg charts to, relative to root unless absolute")
+	flag.Parse()
+
+	report, err := o1.LoadReport(rooted(Report))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		catch(err)
+	}
+	results := report.Results()
+
+	days, err := aoc.ParseDays(Days)
+	catch(err)
+	var records []Record
+	for _, day := range days {
+		attempts, err := o1.Find(Root, day)
+		catch(err)
+		for _, a := range attempts {
+			res, ok := results[a.Path]
+			if !ok {
+				fmt.Fprintf(os.Stderr, "evaluating %s\n", a.Path)
+				res = o1.Eval(context.Background(), Root, a, o1.Options{Timeout: Timeout, BuildTimeout: 2 * time.Minute})
+			}
+			meta, ok, err := o1.ChatMeta(Root, a)
+			catch(err)
+			records = append(records, newRecords(res, meta, ok)...)
+		}
+	}
+
+	stats := summarize(records)
+	printStats(os.Stdout, stats)
+	catch(writeRecords(records))
+	if SVG != "" {
+		catch(writeCharts(rooted(SVG), records, stats))
+	}
+}
+
+// Record is an attempt at a part: a row of the dataset.
+type Record struct {
+	Day     int           `json:"day"`
+	Part    int           `json:"part"`
+	Model   string        `json:"model"`
+	Run     string        `json:"run,omitempty"`
+	Prompt  string        `json:"prompt,omitempty"` // like "ru.4", empty if it's not known
+	Attempt int           `json:"attempt"`          // version in the run
+	Outcome o1.Outcome    `json:"outcome"`
+	Runtime time.Duration `json:"runtime_ns,omitempty"` // of the slowest input, 0 if it didn't run
+	Path    string        `json:"path"`
+}
+
+// newRecords of the parts the run asked for, and has answers to check. An attempt that failed to build
+// failed each of them, and an unchecked one has none, to be left out of success rates.
+func newRecords(res o1.Result, meta o1.Meta, ok bool) []Record {
+	model, prompt := res.Model(), ""
+	if ok {
+		model, prompt = cmp.Or(meta.Model, model), meta.Prompt+"."+strconv.Itoa(meta.PromptVersion)
+	}
+	var records []Record
+	if res.Outcome == o1.Unchecked {
+		return nil
+	}
+	for _, part := range []int{1, 2} {
+		if res.Attempt.Part() != 0 && res.Attempt.Part() != part {
+			continue
+		}
+		outcome, ok := res.Parts[part]
+		if !ok {
+			if len(res.Parts) > 0 || res.Build == "" {
+				continue // no answer of the part
+			}
+			outcome = res.Outcome
+		}
+		r := Record{Day: res.Day, Part: part, Model: model, Run: res.Run, Prompt: prompt, Attempt: res.Version, Outcome: outcome, Path: res.Path}
+		for _, run := range res.Runs {
+			if _, ok := run.Parts[part]; ok {
+				r.Runtime = max(r.Runtime, run.Time)
+			}
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// Stats of parts solved with the same model and prompt.
+type Stats struct {
+	Model    string
+	Prompt   string
+	Parts    int // each part of each run
+	Solved   int
+	FirstTry int
+	Attempts int // to the first correct one, of solved parts
+	Failures map[o1.Outcome]int
+}
+
+// MeanAttempts to solve a part, of solved ones.
+func (s Stats) MeanAttempts() float64 {
+	if s.Solved == 0 {
+		return 0
+	}
+	return float64(s.Attempts) / float64(s.Solved)
+}
+
+// prompt is "?" if it's not known, and empty for the total.
+func (s Stats) prompt() string {
+	if s.Model == "all" {
+		return ""
+	}
+	return cmp.Or(s.Prompt, "?")
+}
+
+type task struct {
+	day, part int
+	run       string
+}
+
+// summarize records by model and prompt, ordered by them, with the total of all of them last.
+func summarize(records []Record) []Stats {
+	first := map[task]int{} // version, or 0 if not solved
+	groups := map[task]string{}
+	for _, r := range records {
+		t := task{r.Day, r.Part, r.Run}
+		if _, ok := first[t]; !ok {
+			first[t] = 0
+		}
+		if r.Outcome == o1.Correct && (first[t] == 0 || r.Attempt < first[t]) {
+			first[t] = r.Attempt
+		}
+		groups[t] = r.Model + "\t" + r.Prompt
+	}
+
+	var stats []Stats
+	total := Stats{Model: "all", Failures: map[o1.Outcome]int{}}
+	get := func(model, prompt string) *Stats {
+		i := slices.IndexFunc(stats, func(s Stats) bool { return s.Model == model && s.Prompt == prompt })
+		if i < 0 {
+			stats = append(stats, Stats{Model: model, Prompt: prompt, Failures: map[o1.Outcome]int{}})
+			i = len(stats) - 1
+		}
+		return &stats[i]
+	}
+	for t, version := range first {
+		model, prompt, _ := strings.Cut(groups[t], "\t")
+		for _, s := range []*Stats{get(model, prompt), &total} {
+			s.Parts++
+			if version > 0 {
+				s.Solved++
+				s.Attempts += version
+			}
+			if version == 1 {
+				s.FirstTry++
+			}
+		}
+	}
+	for _, r := range records {
+		if r.Outcome != o1.Correct {
+			get(r.Model, r.Prompt).Failures[r.Outcome.Kind()]++
+			total.Failures[r.Outcome.Kind()]++
+		}
+	}
+	slices.SortFunc(stats, func(a, b Stats) int {
+		return cmp.Or(cmp.Compare(a.Model, b.Model), cmp.Compare(a.Prompt, b.Prompt))
+	})
+	return append(stats, total)
+}
+
+// failures are outcomes other than correct, from the best to the worst.
+var failures = o1.Outcomes[1:]
+
+func printStats(w io.Writer, stats []Stats) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprint(tw, "Model\tPrompt\tParts\tSolved\tFirst try\tAttempts")
+	for _, o := range failures {
+		fmt.Fprintf(tw, "\t%s", o)
+	}
+	fmt.Fprintln(tw)
+	for _, s := range stats {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%.1f", s.Model, s.prompt(), s.Parts,
+			percent(s.Solved, s.Parts), percent(s.FirstTry, s.Parts), s.MeanAttempts())
+		for _, o := range failures {
+			fmt.Fprintf(tw, "\t%d", s.Failures[o])
+		}
+		fmt.Fprintln(tw)
+	}
+	tw.Flush()
+	fmt.Fprintln(w, "Attempts are to the first correct one, of solved parts. Failures are of all attempts, by what went wrong.")
+}
+
+// percent is like "12 (80%)".
+func percent(n, of int) string {
+	if of == 0 {
+		return strconv.Itoa(n)
+	}
+	return fmt.Sprintf("%d (%d%%)", n, n*100/of)
+}
+
+var header = []string{"day", "part", "model", "run", "prompt", "attempt", "outcome", "runtime_ms", "path"}
+
+func writeCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	for _, r := range records {
+		runtime := ""
+		if r.Runtime > 0 {
+			runtime = strconv.FormatFloat(float64(r.Runtime)/float64(time.Millisecond), 'f', 1, 64)
+		}
+		cw.Write([]string{strconv.Itoa(r.Day), strconv.Itoa(r.Part), r.Model, r.Run, r.Prompt,
+			strconv.Itoa(r.Attempt), string(r.Outcome), runtime, r.Path})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeRecords(records []Record) error {
+	switch {
+	case Out == "":
+		return nil
+	case Out == "-":
+		return writeCSV(os.Stdout, records)
+	}
+	path := rooted(Out)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(path, ".json") {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(records)
+	} else {
+		err = writeCSV(f, records)
+	}
+	if err := cmp.Or(err, f.Close()); err != nil {
+		return err
+	}
+	fmt.Println("Dataset:", path)
+	return nil
+}
+
+// rooted is the path relative to root, unless it's absolute.
+func rooted(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(Root, path)
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
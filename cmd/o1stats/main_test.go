@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
+)
+
+func result(run string, version int, p1, p2 o1.Outcome) o1.Result {
+	res := o1.Result{Attempt: o1.Attempt{Day: 21, Run: run, Version: version, Path: "21/o1/" + run + "/v"}, Parts: map[int]o1.Outcome{}}
+	run1 := o1.Run{Input: "input.txt", Parts: map[int]o1.Outcome{}, Time: time.Duration(version) * time.Second}
+	for part, o := range map[int]o1.Outcome{1: p1, 2: p2} {
+		if o != "" {
+			res.Parts[part], run1.Parts[part] = o, o
+		}
+	}
+	res.Runs = []o1.Run{run1}
+	return res
+}
+
+var (
+	ru4  = o1.Meta{Prompt: "ru", PromptVersion: 4, PromptVars: o1.PromptVars{Model: "o1"}}
+	mini = o1.Meta{Prompt: "ru", PromptVersion: 4, PromptVars: o1.PromptVars{Model: "o1-mini"}}
+)
+
+func testRecords() []Record {
+	compileError := o1.Result{Attempt: o1.Attempt{Day: 21, Version: 3}, Outcome: o1.CompileError, Build: "v3.go:1:1: nope", Parts: map[int]o1.Outcome{}}
+	unchecked := o1.Result{Attempt: o1.Attempt{Day: 21, Run: "run3", Version: 1}, Outcome: o1.Unchecked, Parts: map[int]o1.Outcome{}}
+	var records []Record
+	for _, r := range []struct {
+		res  o1.Result
+		meta o1.Meta
+		ok   bool
+	}{
+		{result("", 1, o1.Incorrect, o1.Panic), ru4, true},
+		{result("", 2, o1.Correct, o1.Timeout), ru4, true},
+		{compileError, ru4, true},
+		{result("", 4, o1.Correct, o1.Correct), ru4, true},
+		{result("run1-o1-mini", 1, o1.Incorrect, o1.Incorrect), mini, true},
+		{result("run2-o1-mini-p1", 1, o1.Correct, ""), o1.Meta{}, false},
+		{unchecked, ru4, true},
+	} {
+		records = append(records, newRecords(r.res, r.meta, r.ok)...)
+	}
+	return records
+}
+
+func TestRecords(t *testing.T) {
+	records := testRecords()
+	require.Len(t, records, 11)
+	assert.Equal(t, Record{Day: 21, Part: 2, Model: "o1", Prompt: "ru.4", Attempt: 1, Outcome: o1.Panic, Runtime: time.Second, Path: "21/o1//v"}, records[1])
+	assert.Equal(t, o1.CompileError, records[4].Outcome, "compile error fails both parts")
+	assert.Equal(t, o1.CompileError, records[5].Outcome)
+	assert.Zero(t, records[5].Runtime)
+	assert.Equal(t, "o1-mini", records[8].Model)
+	assert.Equal(t, Record{Day: 21, Part: 1, Model: "o1-mini", Run: "run2-o1-mini-p1", Attempt: 1, Outcome: o1.Correct, Runtime: time.Second, Path: "21/o1/run2-o1-mini-p1/v"}, records[10],
+		"no part 2 in a p1 run, and no prompt without meta")
+
+	var b bytes.Buffer
+	require.NoError(t, writeCSV(&b, records))
+	lines := strings.Split(b.String(), "\n")
+	assert.Equal(t, "day,part,model,run,prompt,attempt,outcome,runtime_ms,path", lines[0])
+	assert.Equal(t, "21,2,o1,,ru.4,1,panic,1000.0,21/o1//v", lines[2])
+	assert.Equal(t, "21,1,o1,,ru.4,3,compile error,,", lines[5])
+}
+
+func TestSummarize(t *testing.T) {
+	stats := summarize(testRecords())
+	require.Len(t, stats, 4)
+	s := stats[0]
+	assert.Equal(t, "o1 ru.4", s.Model+" "+s.Prompt)
+	assert.Equal(t, 2, s.Parts)
+	assert.Equal(t, 2, s.Solved)
+	assert.Equal(t, 0, s.FirstTry)
+	assert.Equal(t, 3.0, s.MeanAttempts(), "part 1 on v2, part 2 on v4")
+	assert.Equal(t, map[o1.Outcome]int{o1.Incorrect: 1, o1.Panic: 1, o1.Timeout: 1, o1.CompileError: 2}, s.Failures)
+
+	assert.Equal(t, "o1-mini", stats[1].Model)
+	assert.Equal(t, "", stats[1].Prompt, "no meta")
+	assert.Equal(t, 1, stats[1].FirstTry)
+
+	assert.Equal(t, "o1-mini ru.4", stats[2].Model+" "+stats[2].Prompt)
+	assert.Equal(t, 0, stats[2].Solved)
+	assert.Zero(t, stats[2].MeanAttempts())
+
+	total := stats[3]
+	assert.Equal(t, "all", total.Model)
+	assert.Equal(t, 5, total.Parts)
+	assert.Equal(t, 3, total.Solved)
+	assert.Equal(t, 1, total.FirstTry)
+	assert.Equal(t, 3, total.Failures[o1.Incorrect])
+	assert.Equal(t, 2, total.Failures[o1.CompileError])
+
+	var b bytes.Buffer
+	printStats(&b, stats)
+	assert.Contains(t, b.String(), "o1       ru.4    2      2 (100%)  0 (0%)     3.0       1          0      1        1      2\n")
+	assert.Contains(t, b.String(), "o1-mini  ?       1      1 (100%)  1 (100%)")
+	assert.Contains(t, b.String(), "all              5      3 (60%)   1 (20%)    2.3       3 ")
+}
+
+func TestCharts(t *testing.T) {
+	records := testRecords()
+	for name, chart := range map[string]func(io.Writer) error{
+		"outcomes": func(w io.Writer) error { return Outcomes(w, records) },
+		"prompts":  func(w io.Writer) error { return Prompts(w, summarize(records)) },
+	} {
+		var b bytes.Buffer
+		require.NoError(t, chart(&b))
+		dec := xml.NewDecoder(&b)
+		for {
+			_, err := dec.Token()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err, name)
+		}
+	}
+	var b bytes.Buffer
+	require.NoError(t, Outcomes(&b, records))
+	assert.Contains(t, b.String(), "<title>21 run2-o1-mini-p1 p1 v1: correct</title>")
+	assert.Contains(t, b.String(), "<title>21 p2 v3: compile error</title>")
+}
+
+func TestChatMeta(t *testing.T) {
+	for path, want := range map[string]string{
+		"01/o1/v1.go":                            "ru.1",
+		"13/o1/v1.go":                            "ru.3",
+		"15/o1/run2/v4/v4_incorrect.go":          "", // no v1.txt
+		"21/o1/run2-o1-mini-p1/v5/v5.go_invalid": "ru.4",
+		"22/o1/v1/v1.go_invalid":                 "ru.5",
+	} {
+		day, err := strconv.Atoi(path[:2])
+		require.NoError(t, err)
+		attempts, err := o1.Find("../..", day)
+		require.NoError(t, err)
+		i := slices.IndexFunc(attempts, func(a o1.Attempt) bool { return a.Path == path })
+		require.GreaterOrEqual(t, i, 0, path)
+		meta, ok, err := o1.ChatMeta("../..", attempts[i])
+		require.NoError(t, err)
+		if want == "" {
+			assert.False(t, ok, path)
+			continue
+		}
+		require.True(t, ok, path)
+		assert.Equal(t, want, meta.Prompt+"."+strconv.Itoa(meta.PromptVersion), path)
+		assert.Equal(t, attempts[i].Model(), meta.Model)
+	}
+}
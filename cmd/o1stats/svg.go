@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"html"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
+)
+
+// Colors of outcomes in charts.
+var Colors = map[o1.Outcome]string{
+	o1.Correct:      "#3a3",
+	o1.Incorrect:    "#e93",
+	o1.Failed:       "#999",
+	o1.Timeout:      "#36c",
+	o1.Panic:        "#c33",
+	o1.CompileError: "#555",
+}
+
+const (
+	cell  = 16  // size of a square in the outcomes chart
+	label = 220 // width of labels of rows
+	bar   = 300 // width of 100% in the prompts chart
+)
+
+func writeCharts(dir string, records []Record, stats []Stats) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, chart := range []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{"outcomes.svg", func(w io.Writer) error { return Outcomes(w, records) }},
+		{"prompts.svg", func(w io.Writer) error { return Prompts(w, stats) }},
+	} {
+		path := filepath.Join(dir, chart.name)
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		bw := bufio.NewWriter(f)
+		if err := cmp.Or(chart.write(bw), bw.Flush(), f.Close()); err != nil {
+			return err
+		}
+		fmt.Println("Chart:", path)
+	}
+	return nil
+}
+
+// Outcomes is a chart with a row of squares for each part of each run, one per attempt, colored by outcome.
+func Outcomes(w io.Writer, records []Record) error {
+	type row struct {
+		task
+		model    string
+		outcomes map[int]o1.Outcome // by attempt
+	}
+	var rows []*row
+	index := map[task]*row{}
+	width := 0
+	for _, r := range records {
+		t := task{r.Day, r.Part, r.Run}
+		if index[t] == nil {
+			index[t] = &row{task: t, model: r.Model, outcomes: map[int]o1.Outcome{}}
+			rows = append(rows, index[t])
+		}
+		index[t].outcomes[r.Attempt] = r.Outcome.Kind()
+		width = max(width, r.Attempt)
+	}
+
+	height := (len(rows) + 2) * cell
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n",
+		label+max(width, len(o1.Outcomes)*6)*cell, height)
+	for i, r := range rows {
+		y := i * cell
+		name := fmt.Sprintf("%02d", r.day)
+		if r.run != "" {
+			name += " " + r.run
+		}
+		name += fmt.Sprintf(" p%d", r.part)
+		fmt.Fprintf(w, `<text x="0" y="%d">%s</text>`+"\n", y+cell-4, html.EscapeString(name))
+		for _, attempt := range slices.Sorted(maps.Keys(r.outcomes)) {
+			outcome := r.outcomes[attempt]
+			fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"><title>%s v%d: %s</title></rect>`+"\n",
+				label+(attempt-1)*cell, y+1, cell-2, cell-2, Colors[outcome], html.EscapeString(name), attempt, outcome)
+		}
+	}
+	legend(w, height-cell)
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
+
+func legend(w io.Writer, y int) {
+	for i, o := range o1.Outcomes {
+		x := label + i*6*cell
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, y+1, cell-2, cell-2, Colors[o])
+		fmt.Fprintf(w, `<text x="%d" y="%d">%s</text>`+"\n", x+cell, y+cell-4, o)
+	}
+}
+
+// Prompts is a chart of parts solved, and solved on the first try, with each model and prompt.
+func Prompts(w io.Writer, stats []Stats) error {
+	const line = 3 * cell
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n",
+		label+bar+8*cell, (len(stats)+1)*line)
+	for i, s := range stats {
+		y := i * line
+		fmt.Fprintf(w, `<text x="0" y="%d">%s, %d parts</text>`+"\n", y+cell-4, html.EscapeString(strings.TrimSpace(s.Model+" "+s.prompt())), s.Parts)
+		for j, n := range []int{s.Solved, s.FirstTry} {
+			what, color := "solved", Colors[o1.Correct]
+			if j == 1 {
+				what, color = "first try", "#7c7"
+			}
+			fill := 0
+			if s.Parts > 0 {
+				fill = bar * n / s.Parts
+			}
+			fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="#eee"/>`+"\n", label, y+j*cell+1, bar, cell-2)
+			fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", label, y+j*cell+1, fill, cell-2, color)
+			fmt.Fprintf(w, `<text x="%d" y="%d">%s %s</text>`+"\n", label+bar+4, y+(j+1)*cell-4, what, percent(n, s.Parts))
+		}
+	}
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	_ "github.com/metalim/adventofcode.2024.go/aoc/all"
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
+)
+
+// Markers around the generated table in o1.md.
+const (
+	Begin = "<!-- o1summary begin -->"
+	End   = "<!-- o1summary end -->"
+)
+
+var (
+	Root    string
+	Report  string
+	Timeout time.Duration
+	Write   bool
+)
+
+// Regenerate the "Summary of attempts" table of o1.md from NN/o1 folders and the o1eval report
+func main() {
+	flag.StringVar(&Root, "root", ".", "repository root with day folders")
+	flag.StringVar(&Report, "report", "o1/eval.json", "o1eval report, relative to root unless absolute. Attempts missing in it are evaluated")
+	flag.DurationVar(&Timeout, "timeout", 10*time.Second, "time limit per run, for attempts missing in the report")
+	flag.BoolVar(&Write, "w", false, "write o1.md, instead of just printing the table")
+	flag.Parse()
+
+	path := Report
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(Root, path)
+	}
+	report, err := o1.LoadReport(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		catch(err)
+	}
+	results := report.Results()
+
+	doc, err := os.ReadFile(filepath.Join(Root, "o1.md"))
+	catch(err)
+	var days []Day
+	for _, day := range aoc.Days() {
+		attempts, err := o1.Find(Root, day)
+		catch(err)
+		if len(attempts) == 0 {
+			continue
+		}
+		var dayResults []o1.Result
+		for _, a := range attempts {
+			res, ok := results[a.Path]
+			if !ok {
+				fmt.Fprintf(os.Stderr, "evaluating %s\n", a.Path)
+				res = o1.Eval(context.Background(), Root, a, o1.Options{Timeout: Timeout, BuildTimeout: 2 * time.Minute})
+			}
+			dayResults = append(dayResults, res)
+		}
+		days = append(days, summarize(day, dayResults))
+	}
+
+	table, err := Table(string(doc), days)
+	catch(err)
+	if !Write {
+		fmt.Print(table)
+		return
+	}
+	updated, err := Replace(string(doc), table)
+	catch(err)
+	catch(os.WriteFile(filepath.Join(Root, "o1.md"), []byte(updated), 0644))
+}
+
+// Day is a row of the table.
+type Day struct {
+	Day  int
+	Runs []Run // in order they were done
+}
+
+// Run is a chat with the model, with the first version correct for each part, 0 if none, or -1 if the part was not asked.
+type Run struct {
+	Name    string
+	Model   string
+	First   [3]int  // by part
+	Checked [3]bool // by part, false if no version was checked against answers
+}
+
+// summarize finds the first correct version of each part in each run. Runs are in order of their folders,
+// with the main one, not in a folder, last.
+func summarize(day int, results []o1.Result) Day {
+	d := Day{Day: day}
+	for _, res := range results {
+		i := slices.IndexFunc(d.Runs, func(r Run) bool { return r.Name == res.Run })
+		if i < 0 {
+			run := Run{Name: res.Run, Model: res.Model()}
+			for part := 1; part <= 2; part++ {
+				if res.Attempt.Part() != 0 && res.Attempt.Part() != part {
+					run.First[part] = -1
+				}
+			}
+			d.Runs = append(d.Runs, run)
+			i = len(d.Runs) - 1
+		}
+		run := &d.Runs[i]
+		for part := 1; part <= 2; part++ {
+			if _, ok := res.Parts[part]; ok || res.Build != "" { // failed to build is checked, and failed
+				run.Checked[part] = true
+			}
+			if run.First[part] == 0 && res.Parts[part] == o1.Correct {
+				run.First[part] = res.Version
+			}
+		}
+	}
+	slices.SortStableFunc(d.Runs, func(a, b Run) int {
+		switch {
+		case a.Name == b.Name:
+			return 0
+		case a.Name == "":
+			return 1
+		case b.Name == "":
+			return -1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return d
+}
+
+// Cell is the number of versions it took to solve the part in each run, separated by " / ".
+// Runs of other models say which. More than one version, or not solving at all, is in bold.
+// Runs that weren't checked are "?", and are not counted as not solving it.
+func (d Day) Cell(part int) string {
+	var cells []string
+	var solved, checked bool
+	for _, r := range d.Runs {
+		var cell string
+		switch n := r.First[part]; {
+		case n < 0:
+			continue
+		case !r.Checked[part]:
+			cell = "?"
+		case n == 0:
+			cell, checked = "—", true
+		default:
+			cell = strconv.Itoa(n)
+			solved, checked = true, true
+		}
+		if r.Model != "o1" {
+			cell += " (" + r.Model + ")"
+		}
+		cells = append(cells, cell)
+	}
+	switch {
+	case !checked && len(cells) > 0:
+		return strings.Join(cells, " / ")
+	case !solved:
+		return "**——**"
+	case len(cells) == 1 && cells[0] != "1":
+		return "**" + cells[0] + "**"
+	}
+	return strings.Join(cells, " / ")
+}
+
+// Table is the summary table, with links to sections of the notes in doc, and chat links kept from the table in doc.
+func Table(doc string, days []Day) (string, error) {
+	anchors := Anchors(doc)
+	chats, err := Chats(doc)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintln(&b, "| Day | Part1 | Part2 | Solution | Chat URL |")
+	fmt.Fprintln(&b, "| --- | --- | --- | --- | --- |")
+	for _, d := range days {
+		day := strconv.Itoa(d.Day)
+		if anchor, ok := anchors[d.Day]; ok {
+			day = fmt.Sprintf("[%d](#%s)", d.Day, anchor)
+		}
+		dir := aoc.Dir(d.Day) + "/o1/"
+		fmt.Fprintf(&b, "| %s | %s | %s | [%s](%s) | %s |\n", day, d.Cell(1), d.Cell(2), dir, dir, chats[d.Day])
+	}
+	return b.String(), nil
+}
+
+var (
+	reHeading = regexp.MustCompile(`(?m)^## (Days? (\d+)(?:-(\d+))?.*)$`)
+	reRow     = regexp.MustCompile(`(?m)^\|\s*\[?(\d+)\]?[^|]*\|(?:[^|]*\|){3}\s*(.*?)\s*\|?\s*$`)
+	reEmpty   = regexp.MustCompile(`\[([^\]]*)\]\(\)`)
+	reNotSlug = regexp.MustCompile(`[^\pL\pN -]`)
+)
+
+// Anchors are GitHub anchors of note sections of each day, like "days-1-6" for "## Days 1-6".
+func Anchors(doc string) map[int]string {
+	anchors := map[int]string{}
+	for _, m := range reHeading.FindAllStringSubmatch(doc, -1) {
+		first, _ := strconv.Atoi(m[2])
+		last := first
+		if m[3] != "" {
+			last, _ = strconv.Atoi(m[3])
+		}
+		slug := strings.ReplaceAll(reNotSlug.ReplaceAllString(strings.ToLower(strings.TrimSpace(m[1])), ""), " ", "-")
+		for day := first; day <= last; day++ {
+			if _, ok := anchors[day]; !ok {
+				anchors[day] = slug
+			}
+		}
+	}
+	return anchors
+}
+
+// Chats are Chat URL cells of the table in doc. Links are not in the archive, so they are kept as they are,
+// except for empty ones, which become plain text.
+func Chats(doc string) (map[int]string, error) {
+	start := strings.Index(doc, "| Day ")
+	if start < 0 {
+		return nil, errors.New("no table with \"| Day \" header to take chat links from")
+	}
+	chats := map[int]string{}
+	section, _, _ := strings.Cut(doc[start:], "\n\n")
+	for _, m := range reRow.FindAllStringSubmatch(section, -1) {
+		day, _ := strconv.Atoi(m[1])
+		chats[day] = reEmpty.ReplaceAllString(m[2], "$1")
+	}
+	return chats, nil
+}
+
+// Replace puts the table between the markers in doc. If there are no markers yet,
+// the first table after "## Summary of attempts" is replaced, and the markers are added around it.
+func Replace(doc, table string) (string, error) {
+	table = Begin + "\n\n" + table + "\n" + End
+	if begin, end := strings.Index(doc, Begin), strings.Index(doc, End); begin >= 0 && end > begin {
+		return doc[:begin] + table + doc[end+len(End):], nil
+	}
+	heading := strings.Index(doc, "## Summary of attempts")
+	if heading < 0 {
+		return "", errors.New("o1.md has neither markers, nor \"## Summary of attempts\"")
+	}
+	start := heading + strings.Index(doc[heading:], "\n|")
+	if start < heading {
+		return "", errors.New("no table under \"## Summary of attempts\"")
+	}
+	start++
+	end := start + strings.Index(doc[start:], "\n\n")
+	if end < start {
+		end = len(doc)
+	}
+	return doc[:start] + table + doc[end:], nil
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
+)
+
+const doc = `# o1
+
+## Summary of attempts
+
+| Day | Part1 | Part2 | Solution | Chat URL |
+| --- | --- | --- | --- | --- |
+| [5](#days-1-6) | 1 | 1 | [05/o1/](05/o1/) | [Day 5](https://chat/5) |
+| [21](#day-21) | **10** | **——** | [21/o1/](21/o1/) | runs [1]() [2]() [3](https://chat/21)|
+
+## Days 1-6
+
+Notes.
+
+## Day 21:
+
+More notes.
+`
+
+// result with outcomes of parts, "" for not checked.
+func result(run string, version int, p1, p2 o1.Outcome) o1.Result {
+	res := o1.Result{Attempt: o1.Attempt{Day: 21, Run: run, Version: version}, Parts: map[int]o1.Outcome{}}
+	for part, o := range map[int]o1.Outcome{1: p1, 2: p2} {
+		if o != "" {
+			res.Parts[part] = o
+		}
+	}
+	return res
+}
+
+func TestTable(t *testing.T) {
+	days := []Day{
+		summarize(5, []o1.Result{result("", 1, o1.Correct, o1.Correct)}),
+		summarize(21, []o1.Result{
+			result("", 1, o1.Incorrect, o1.Incorrect),
+			result("", 2, o1.Correct, o1.Timeout),
+			result("run1-o1-mini", 1, o1.Incorrect, o1.Incorrect),
+			result("run2-o1-mini-p1", 1, o1.Incorrect, ""),
+			result("run2-o1-mini-p1", 2, o1.Correct, ""),
+		}),
+	}
+	table, err := Table(doc, days)
+	require.NoError(t, err)
+	assert.Equal(t, "| Day | Part1 | Part2 | Solution | Chat URL |\n| --- | --- | --- | --- | --- |\n"+
+		"| [5](#days-1-6) | 1 | 1 | [05/o1/](05/o1/) | [Day 5](https://chat/5) |\n"+
+		"| [21](#day-21) | — (o1-mini) / 2 (o1-mini) / 2 | **——** | [21/o1/](21/o1/) | runs 1 2 [3](https://chat/21) |\n",
+		table)
+
+	_, err = Table("# o1\n\n## Summary of attempts\n\nNo table yet.\n", days)
+	assert.ErrorContains(t, err, "no table")
+
+	updated, err := Replace(doc, "| new |\n")
+	require.NoError(t, err)
+	assert.Contains(t, updated, "## Summary of attempts\n\n"+Begin+"\n\n| new |\n\n"+End+"\n\n## Days 1-6\n\nNotes.")
+
+	again, err := Replace(updated, "| newer |\n")
+	require.NoError(t, err)
+	assert.Contains(t, again, "## Summary of attempts\n\n"+Begin+"\n\n| newer |\n\n"+End+"\n\n## Days 1-6\n\nNotes.")
+	assert.Equal(t, len(updated)+2, len(again))
+}
+
+func TestCell(t *testing.T) {
+	d := summarize(7, []o1.Result{
+		result("", 1, o1.Correct, o1.Incorrect),
+		result("", 2, o1.Correct, o1.CompileError),
+		result("", 3, o1.Correct, o1.Correct),
+	})
+	assert.Equal(t, "1", d.Cell(1))
+	assert.Equal(t, "**3**", d.Cell(2))
+
+	d = summarize(8, []o1.Result{
+		result("", 1, o1.Correct, ""),
+		result("run1-o1-mini", 1, o1.Incorrect, ""),
+	})
+	assert.Equal(t, "— (o1-mini) / 1", d.Cell(1))
+	assert.Equal(t, "? (o1-mini) / ?", d.Cell(2), "no answers of part 2 is not a failure")
+
+	compileError := o1.Result{Attempt: o1.Attempt{Day: 8, Version: 1}, Outcome: o1.CompileError, Build: "nope", Parts: map[int]o1.Outcome{}}
+	d = summarize(8, []o1.Result{compileError})
+	assert.Equal(t, "**——**", d.Cell(2))
+}
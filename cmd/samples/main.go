@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	_ "github.com/metalim/adventofcode.2024.go/aoc/all"
+	"github.com/metalim/adventofcode.2024.go/aoc/aoctest"
+	"github.com/metalim/adventofcode.2024.go/aoc/task"
+)
+
+const TestFile = "samples_test.go"
+
+var (
+	Root    string
+	Days    string
+	Write   bool
+	Verbose bool
+)
+
+// Pull examples with answers out of NN/task.txt, into sample files and NN/dayNN/samples_test.go
+func main() {
+	flag.StringVar(&Root, "root", ".", "repository root with day folders")
+	flag.StringVar(&Days, "day", "all", "days to generate, like 5, 5-12 or 1,3,20-")
+	flag.BoolVar(&Write, "w", false, "write sample files and tests, instead of just printing what was found")
+	flag.BoolVar(&Verbose, "v", false, "print example inputs too")
+	flag.Parse()
+
+	days, err := aoc.ParseDays(Days)
+	catch(err)
+	aoc.Log = io.Discard
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tFile\tPart\tWant\tGot")
+	for _, day := range days {
+		samples := generate(day)
+		for _, s := range samples {
+			got := s.Want
+			if s.Skip != "" {
+				got = s.Skip
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\n", day, s.File, s.Part, s.Want, got)
+		}
+	}
+	tw.Flush()
+}
+
+// generate finds examples of the day, runs the solver on them, and writes the files if asked.
+// Answers recorded in answers.json for sample files win over ones found in the task: examples
+// with other answers are dropped, and sample files the task gave no answers for are tested with them.
+func generate(day int) []aoctest.Sample {
+	dir := filepath.Join(Root, aoc.Dir(day))
+	text, err := os.ReadFile(filepath.Join(dir, "task.txt"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	catch(err)
+	like, err := os.ReadFile(filepath.Join(dir, "input.txt"))
+	if err != nil && !os.IsNotExist(err) {
+		catch(err)
+	}
+
+	known, err := aoc.LoadAnswers(Root, day)
+	catch(err)
+
+	puzzle, _ := aoc.Get(day)
+	taken := map[string]bool{}
+	var samples []aoctest.Sample
+	for _, ex := range task.Examples(string(text), string(like)) {
+		if len(ex.Answers) == 0 {
+			continue
+		}
+		file, existed := sampleFile(dir, ex.Input, taken)
+		taken[file] = true
+		if Verbose {
+			fmt.Printf("%s:\n%s\n", filepath.Join(dir, file), ex.Input)
+		}
+		if Write && !existed {
+			catch(os.WriteFile(filepath.Join(dir, file), []byte(ex.Input), 0644))
+		}
+		for _, part := range puzzle.Parts() {
+			want, ok := ex.Answers[part]
+			if !ok {
+				continue
+			}
+			if answer, ok := known.Get(file, part); ok && answer != want {
+				fmt.Fprintf(os.Stderr, "day %d: dropped %s part %d: task gives %s, %s has %s\n", day, file, part, want, aoc.AnswersFile, answer)
+				continue
+			}
+			if s, ok := check(puzzle, file, ex.Input, part, want); ok {
+				samples = append(samples, s)
+			}
+		}
+	}
+	for _, file := range slices.Sorted(maps.Keys(known)) {
+		if !strings.HasPrefix(file, "sample") || strings.Contains(file, "/") {
+			continue
+		}
+		for _, part := range puzzle.Parts() {
+			want, ok := known.Get(file, part)
+			if !ok || slices.ContainsFunc(samples, func(s aoctest.Sample) bool { return s.File == file && s.Part == part }) {
+				continue
+			}
+			input, err := os.ReadFile(filepath.Join(dir, file))
+			catch(err)
+			if s, ok := check(puzzle, file, string(input), part, want); ok {
+				samples = append(samples, s)
+			}
+		}
+	}
+	slices.SortStableFunc(samples, func(a, b aoctest.Sample) int {
+		return cmp.Or(strings.Compare(a.File, b.File), a.Part-b.Part)
+	})
+	if Write && len(samples) > 0 {
+		writeTest(day, samples)
+	}
+	return samples
+}
+
+// check runs the solver on the sample, and tells the test to skip it if the answer is not the one wanted.
+func check(puzzle aoc.Puzzle, file, input string, part int, want string) (aoctest.Sample, bool) {
+	s := aoctest.Sample{File: file, Part: part, Want: want}
+	res := puzzle.Run(aoc.WithInput(context.Background(), file), input, 0, part)
+	switch {
+	case len(res) == 0:
+		return s, false
+	case res[0].Error != "":
+		s.Skip = "solver fails: " + res[0].Error
+	case res[0].Answer != want:
+		s.Skip = "solver gives " + res[0].Answer
+	}
+	return s, true
+}
+
+// sampleFile finds sample file with the same content, or picks a free name for a new one.
+func sampleFile(dir, input string, taken map[string]bool) (name string, existed bool) {
+	for i := 1; ; i++ {
+		name = "sample.txt"
+		if i > 1 {
+			name = fmt.Sprintf("sample%d.txt", i)
+		}
+		if taken[name] {
+			continue
+		}
+		bs, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			break
+		}
+		catch(err)
+		if strings.TrimSpace(string(bs)) == strings.TrimSpace(input) {
+			return name, true
+		}
+	}
+	// no file has it, take the first free name
+	for i := 1; ; i++ {
+		name = "sample.txt"
+		if i > 1 {
+			name = fmt.Sprintf("sample%d.txt", i)
+		}
+		if _, err := os.Stat(filepath.Join(dir, name)); os.IsNotExist(err) && !taken[name] {
+			return name, false
+		}
+	}
+}
+
+func writeTest(day int, samples []aoctest.Sample) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by go run ./cmd/samples -w; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package day%02d\n\n", day)
+	fmt.Fprintf(&b, "import (\n\"testing\"\n\n\"github.com/metalim/adventofcode.2024.go/aoc/aoctest\"\n)\n\n")
+	fmt.Fprintf(&b, "func TestSamples(t *testing.T) {\naoctest.Samples(t, %d, []aoctest.Sample{\n", day)
+	for _, s := range samples {
+		fmt.Fprintf(&b, "{File: %q, Part: %d, Want: %q", s.File, s.Part, s.Want)
+		if s.Skip != "" {
+			fmt.Fprintf(&b, ", Skip: %q", s.Skip)
+		}
+		fmt.Fprintf(&b, "},\n")
+	}
+	fmt.Fprintf(&b, "})\n}\n")
+	src, err := format.Source(b.Bytes())
+	catch(err)
+	path := filepath.Join(Root, aoc.Dir(day), fmt.Sprintf("day%02d", day), TestFile)
+	catch(os.WriteFile(path, src, 0644))
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
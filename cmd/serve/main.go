@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	_ "github.com/metalim/adventofcode.2024.go/aoc/all"
+	"github.com/metalim/adventofcode.2024.go/aoc/vis"
+)
+
+var (
+	Addr     string
+	Timeout  time.Duration
+	MaxInput int64
+)
+
+// Serve a page to run any day on a pasted or uploaded input
+func main() {
+	flag.StringVar(&Addr, "addr", "localhost:8024", "address to listen on")
+	flag.DurationVar(&Timeout, "timeout", 10*time.Second, "time limit per request, for all parts together")
+	flag.Int64Var(&MaxInput, "max", 1<<20, "max input size in bytes")
+	flag.Parse()
+	aoc.Log = io.Discard
+
+	fmt.Printf("Serving on http://%s\n", Addr)
+	catch(http.ListenAndServe(Addr, NewServer(Timeout, MaxInput)))
+}
+
+//go:embed page.html
+var pageHTML string
+
+var page = template.Must(template.New("page").Parse(pageHTML))
+
+// Server runs solvers in-process. Solvers keep settings and visualizations in package variables,
+// so only one request runs a day at a time.
+type Server struct {
+	Timeout  time.Duration
+	MaxInput int64
+
+	mu   sync.Mutex
+	busy map[int]bool // days being run, or with a part still running after its request timed out
+}
+
+func NewServer(timeout time.Duration, maxInput int64) http.Handler {
+	s := &Server{Timeout: timeout, MaxInput: maxInput, busy: map[int]bool{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.index)
+	mux.HandleFunc("POST /{$}", s.solve)
+	return mux
+}
+
+// Page is data of the page template.
+type Page struct {
+	Days    []int
+	VisDays []int
+	Day     int
+	Input   string
+	Vis     bool
+	Error   string
+	Results []Result
+	Timeout time.Duration
+}
+
+// Result of a part, with its visualization, if asked for.
+type Result struct {
+	aoc.Result
+	GIF template.URL // data: URL
+}
+
+func (s *Server) page() Page {
+	p := Page{Days: aoc.Days(), Timeout: s.Timeout}
+	for day := range visDays {
+		p.VisDays = append(p.VisDays, day)
+	}
+	slices.Sort(p.VisDays)
+	return p
+}
+
+func (s *Server) index(w http.ResponseWriter, r *http.Request) {
+	p := s.page()
+	p.Day, _ = strconv.Atoi(r.FormValue("day"))
+	s.render(w, http.StatusOK, p)
+}
+
+func (s *Server) solve(w http.ResponseWriter, r *http.Request) {
+	p := s.page()
+	input, err := s.readForm(w, r, &p)
+	if err != nil {
+		p.Error = err.Error()
+		s.render(w, http.StatusBadRequest, p)
+		return
+	}
+	puzzle, ok := aoc.Get(p.Day)
+	if !ok {
+		p.Error = fmt.Sprintf("day %d is not registered", p.Day)
+		s.render(w, http.StatusBadRequest, p)
+		return
+	}
+	p.Results, err = s.run(r.Context(), puzzle, input, p.Vis)
+	if err != nil {
+		p.Error = err.Error()
+		s.render(w, http.StatusServiceUnavailable, p)
+		return
+	}
+	s.render(w, http.StatusOK, p)
+}
+
+// readForm reads the day and the input, from the uploaded file or the text area.
+func (s *Server) readForm(w http.ResponseWriter, r *http.Request, p *Page) (string, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, s.MaxInput+1<<16) // and some for other fields
+	if err := r.ParseMultipartForm(s.MaxInput); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return "", err
+	}
+	var err error
+	p.Day, err = strconv.Atoi(r.FormValue("day"))
+	if err != nil {
+		return "", fmt.Errorf("bad day %q", r.FormValue("day"))
+	}
+	p.Vis = r.FormValue("vis") != ""
+	p.Input = r.FormValue("input")
+	file, _, err := r.FormFile("file")
+	if err == nil {
+		defer file.Close()
+		bs, err := io.ReadAll(io.LimitReader(file, s.MaxInput+1))
+		if err != nil {
+			return "", err
+		}
+		if len(bs) > 0 {
+			p.Input = string(bs)
+		}
+	}
+	if int64(len(p.Input)) > s.MaxInput {
+		return "", fmt.Errorf("input is over %d bytes", s.MaxInput)
+	}
+	if strings.TrimSpace(p.Input) == "" {
+		return "", errors.New("no input")
+	}
+	return strings.ReplaceAll(p.Input, "\r\n", "\n"), nil
+}
+
+// run runs the parts one after another, all within the time limit. Parts that check ctx stop
+// when it's done. The rest keep running in background, as Solve can't stop them, and the day
+// stays busy, with its visualization still on, until they return.
+func (s *Server) run(ctx context.Context, puzzle aoc.Puzzle, input string, withVis bool) ([]Result, error) {
+	s.mu.Lock()
+	if s.busy[puzzle.Day] {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("day %d is still running, try again later", puzzle.Day)
+	}
+	s.busy[puzzle.Day] = true
+	s.mu.Unlock()
+	vd, hasVis := visDays[puzzle.Day]
+	var running *vis.Recorder // of the part left running
+	release := func() {
+		running.Close()
+		if hasVis {
+			vd.set(nil)
+		}
+		s.mu.Lock()
+		delete(s.busy, puzzle.Day)
+		s.mu.Unlock()
+	}
+
+	deadline := time.Now().Add(s.Timeout)
+	var results []Result
+	for _, part := range puzzle.Parts() {
+		left := time.Until(deadline).Round(time.Millisecond)
+		if left <= 0 {
+			results = append(results, Result{Result: aoc.Result{Day: puzzle.Day, Part: part, Timeout: true,
+				Error: fmt.Sprintf("no time left of %v", s.Timeout)}})
+			continue
+		}
+		if puzzle.Running() {
+			results = append(results, Result{Result: aoc.Result{Day: puzzle.Day, Part: part,
+				Error: "not run, the previous part is still running"}})
+			continue
+		}
+		var rec *vis.Recorder
+		var fs *frames
+		if withVis && hasVis {
+			fs = newFrames()
+			rec = vis.New(time.Second/time.Duration(vd.fps), fs)
+			vd.set(rec)
+		}
+		res := puzzle.Run(ctx, input, left, part)
+		if len(res) == 0 {
+			continue
+		}
+		result := Result{Result: res[0]}
+		if puzzle.Running() {
+			// it may still record frames
+			running = rec
+			results = append(results, result)
+			continue
+		}
+		if hasVis {
+			vd.set(nil)
+		}
+		if err := rec.Close(); err != nil {
+			if result.Error != "" {
+				result.Error += "; "
+			}
+			result.Error += "visualization: " + err.Error()
+		}
+		if fs != nil && fs.gif != nil {
+			result.GIF = template.URL("data:image/gif;base64," + base64.StdEncoding.EncodeToString(fs.gif))
+		}
+		results = append(results, result)
+	}
+	if puzzle.Running() {
+		go func() {
+			puzzle.Wait()
+			release()
+		}()
+	} else {
+		release()
+	}
+	return results, nil
+}
+
+func (s *Server) render(w http.ResponseWriter, status int, p Page) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := page.Execute(w, p); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/vis"
+)
+
+// stuck is day 26, with part 1 not checking ctx, and running until release is closed.
+type stuck struct{}
+
+var release = make(chan struct{})
+
+func init() {
+	aoc.Register(26, stuck{})
+}
+
+func (stuck) Parse(input string) (string, error) { return input, nil }
+func (stuck) Part1(ctx context.Context, input string) (aoc.Answer, error) {
+	<-release
+	return aoc.Int(1), nil
+}
+func (stuck) Part2(ctx context.Context, input string) (aoc.Answer, error) { return aoc.Int(2), nil }
+
+func sample(t *testing.T, file string) string {
+	bs, err := os.ReadFile("../../" + file)
+	require.NoError(t, err)
+	return string(bs)
+}
+
+func post(t *testing.T, srv *httptest.Server, form url.Values) (int, string) {
+	resp, err := http.PostForm(srv.URL, form)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var b bytes.Buffer
+	_, err = b.ReadFrom(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, b.String()
+}
+
+func TestIndex(t *testing.T) {
+	srv := httptest.NewServer(NewServer(time.Minute, 1<<20))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/?day=5")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var b bytes.Buffer
+	b.ReadFrom(resp.Body)
+	assert.Contains(t, b.String(), `<option value="5" selected>5</option>`)
+	assert.Contains(t, b.String(), `<option value="25">25</option>`)
+
+	resp, err = http.Get(srv.URL + "/nothing")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSolve(t *testing.T) {
+	srv := httptest.NewServer(NewServer(time.Minute, 1<<20))
+	defer srv.Close()
+	status, body := post(t, srv, url.Values{"day": {"5"}, "input": {sample(t, "05/sample.txt")}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `<td class="answer">143</td>`)
+	assert.Contains(t, body, `<td class="answer">123</td>`)
+	assert.NotContains(t, body, "<img")
+}
+
+func TestUpload(t *testing.T) {
+	srv := httptest.NewServer(NewServer(time.Minute, 1<<20))
+	defer srv.Close()
+	var b bytes.Buffer
+	mw := multipart.NewWriter(&b)
+	mw.WriteField("day", "5")
+	mw.WriteField("input", "ignored, as the file is given")
+	fw, err := mw.CreateFormFile("file", "sample.txt")
+	require.NoError(t, err)
+	fw.Write([]byte(sample(t, "05/sample.txt")))
+	require.NoError(t, mw.Close())
+
+	resp, err := http.Post(srv.URL, mw.FormDataContentType(), &b)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var body bytes.Buffer
+	body.ReadFrom(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body.String(), `<td class="answer">143</td>`)
+}
+
+func TestBadRequests(t *testing.T) {
+	srv := httptest.NewServer(NewServer(time.Minute, 100))
+	defer srv.Close()
+	for _, tc := range []struct {
+		form url.Values
+		want string
+	}{
+		{url.Values{"day": {"x"}, "input": {"1"}}, `bad day &#34;x&#34;`},
+		{url.Values{"day": {"30"}, "input": {"1"}}, "day 30 is not registered"},
+		{url.Values{"day": {"5"}, "input": {" \n"}}, "no input"},
+		{url.Values{"day": {"5"}, "input": {strings.Repeat("1", 101)}}, "input is over 100 bytes"},
+	} {
+		status, body := post(t, srv, tc.form)
+		assert.Equal(t, http.StatusBadRequest, status, tc.want)
+		assert.Contains(t, body, tc.want)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	srv := httptest.NewServer(NewServer(time.Minute, 1<<20))
+	defer srv.Close()
+	status, body := post(t, srv, url.Values{"day": {"18"}, "input": {"1,2\nnope\n"}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `<td class="error">`)
+	assert.Contains(t, body, "2:1")
+}
+
+func TestTimeout(t *testing.T) {
+	srv := httptest.NewServer(NewServer(time.Millisecond, 1<<20))
+	defer srv.Close()
+	status, body := post(t, srv, url.Values{"day": {"24"}, "input": {sample(t, "24/input.txt")}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `<td class="timeout">`)
+}
+
+func TestLeftRunning(t *testing.T) {
+	srv := httptest.NewServer(NewServer(10*time.Millisecond, 1<<20))
+	defer srv.Close()
+	status, body := post(t, srv, url.Values{"day": {"26"}, "input": {"x"}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `<td class="timeout">`)
+	puzzle, _ := aoc.Get(26)
+	require.True(t, puzzle.Running())
+
+	// the day is busy until the part returns, other days are not
+	status, body = post(t, srv, url.Values{"day": {"26"}, "input": {"x"}})
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Contains(t, body, "day 26 is still running, try again later")
+	status, _ = post(t, srv, url.Values{"day": {"5"}, "input": {sample(t, "05/sample.txt")}})
+	assert.Equal(t, http.StatusOK, status)
+
+	close(release)
+	puzzle.Wait()
+	assert.Eventually(t, func() bool {
+		status, _ := post(t, srv, url.Values{"day": {"26"}, "input": {"x"}})
+		return status == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestVis(t *testing.T) {
+	srv := httptest.NewServer(NewServer(time.Minute, 1<<20))
+	defer srv.Close()
+	status, body := post(t, srv, url.Values{"day": {"15"}, "vis": {"on"}, "input": {sample(t, "15/sample.txt")}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 2, strings.Count(body, `<img src="data:image/gif;base64,`))
+
+	// and it's off for the next request
+	status, body = post(t, srv, url.Values{"day": {"15"}, "input": {sample(t, "15/sample.txt")}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.NotContains(t, body, "<img")
+}
+
+func TestFrames(t *testing.T) {
+	MaxFrames = 10
+	defer func() { MaxFrames = 300 }()
+	fs := newFrames()
+	for i := range 95 {
+		f := vis.NewFrame(3, 2)
+		f.Text = strconv.Itoa(i)
+		require.NoError(t, fs.Frame(f, time.Millisecond))
+	}
+	require.NoError(t, fs.Close())
+	var got []string
+	for _, f := range fs.frames {
+		got = append(got, f.Text)
+	}
+	assert.Equal(t, []string{"0", "16", "32", "48", "64", "80", "94"}, got, "every 16th frame, and the last one")
+	assert.NotEmpty(t, fs.gif)
+}
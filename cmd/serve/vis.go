@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/10/day10"
+	"github.com/metalim/adventofcode.2024.go/14/day14"
+	"github.com/metalim/adventofcode.2024.go/15/day15"
+	"github.com/metalim/adventofcode.2024.go/18/day18"
+	"github.com/metalim/adventofcode.2024.go/aoc/vis"
+)
+
+// visDay turns visualization of the day on, with frames going to r, or off with nil r.
+type visDay struct {
+	fps int // same as in main of the day
+	set func(r *vis.Recorder)
+}
+
+var visDays = map[int]visDay{
+	10: {2, func(r *vis.Recorder) { day10.PRINT_MAP, day10.Vis = r != nil, r }},
+	14: {2, func(r *vis.Recorder) { day14.Print, day14.Vis = r != nil, r }},
+	15: {20, func(r *vis.Recorder) { day15.Print, day15.Vis = r != nil, r }},
+	18: {1, func(r *vis.Recorder) { day18.PrintGrid, day18.Vis = r != nil, r }},
+}
+
+// MaxFrames in a GIF. Longer recordings keep every 2nd, 4th, ... frame, and the last one.
+var MaxFrames = 300
+
+// MaxWidth of a GIF in pixels. Cells are scaled up to fit it.
+const MaxWidth = 800
+
+// frames keeps up to MaxFrames evenly spread frames, and makes a GIF of them on Close.
+type frames struct {
+	frames []vis.Frame
+	last   *vis.Frame // if not kept
+	n      int
+	stride int
+	delay  time.Duration
+	gif    []byte
+}
+
+func newFrames() *frames {
+	return &frames{stride: 1}
+}
+
+func (fs *frames) Frame(f vis.Frame, delay time.Duration) error {
+	fs.delay = delay
+	fs.n++
+	if (fs.n-1)%fs.stride != 0 {
+		fs.last = &f
+		return nil
+	}
+	fs.last = nil
+	fs.frames = append(fs.frames, f)
+	if len(fs.frames) > MaxFrames {
+		// frames 0, stride, 2*stride... become 0, 2*stride...
+		kept := fs.frames[:0]
+		for i := 0; i < len(fs.frames); i += 2 {
+			kept = append(kept, fs.frames[i])
+		}
+		fs.frames = kept
+		fs.stride *= 2
+	}
+	return nil
+}
+
+func (fs *frames) Close() error {
+	if fs.last != nil {
+		fs.frames = append(fs.frames, *fs.last)
+	}
+	if len(fs.frames) == 0 {
+		return nil
+	}
+	var w int
+	for _, f := range fs.frames {
+		w = max(w, f.W)
+	}
+	var b bytes.Buffer
+	sink := vis.NewGIF(&b, max(1, min(8, MaxWidth/max(w, 1))))
+	for _, f := range fs.frames {
+		if err := sink.Frame(f, fs.delay); err != nil {
+			return err
+		}
+	}
+	if err := sink.Close(); err != nil {
+		return err
+	}
+	fs.gif = b.Bytes()
+	return nil
+}
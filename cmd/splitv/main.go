@@ -1,6 +1,8 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"io/fs"
 	"os"
@@ -8,12 +10,33 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+
+	"github.com/metalim/adventofcode.2024.go/aoc/o1"
 )
 
 const SuffGo = ".go"
 const SuffTxt = ".txt"
+const SuffJSON = ".json"
+
+var (
+	JSON bool
+	Text bool
+)
 
+// Move the leading comment of each vN.go into vN.txt, and with -json write vN.json with vN.txt in parts
 func main() {
+	flag.BoolVar(&JSON, "json", false, "also write vN.json next to each vN.txt in o1: prompt, runs, output, answers, compile errors, comments")
+	flag.BoolVar(&Text, "text", false, "print the text form of vN.json files given as args")
+	flag.Parse()
+	if Text {
+		for _, path := range flag.Args() {
+			text, err := toText(path)
+			catch(err)
+			fmt.Print(text)
+		}
+		return
+	}
+
 	filepath.WalkDir(".", func(pathGo string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -30,6 +53,48 @@ func main() {
 		}
 		return nil
 	})
+	if !JSON {
+		return
+	}
+	filepath.WalkDir(".", func(pathTxt string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !reTxt.MatchString(filepath.Base(pathTxt)) || !slices.Contains(strings.Split(filepath.ToSlash(pathTxt), "/"), "o1") {
+			return nil
+		}
+		catch(toJSON(pathTxt))
+		return nil
+	})
+}
+
+var reTxt = regexp.MustCompile(`^v\d+\.txt$`)
+
+// toJSON writes vN.json with vN.txt in parts.
+func toJSON(pathTxt string) error {
+	text, err := os.ReadFile(pathTxt)
+	if err != nil {
+		return err
+	}
+	bs, err := json.MarshalIndent(o1.ParseTranscript(string(text)), "", "  ")
+	if err != nil {
+		return err
+	}
+	bs = append(bs, '\n')
+	return os.WriteFile(strings.TrimSuffix(pathTxt, SuffTxt)+SuffJSON, bs, 0644)
+}
+
+// toText is vN.txt made from vN.json.
+func toText(pathJSON string) (string, error) {
+	bs, err := os.ReadFile(pathJSON)
+	if err != nil {
+		return "", err
+	}
+	var t o1.Transcript
+	if err := json.Unmarshal(bs, &t); err != nil {
+		return "", fmt.Errorf("%s: %w", pathJSON, err)
+	}
+	return t.String(), nil
 }
 
 var reV = regexp.MustCompile(`^v\d+`)
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/site"
+)
+
+// AttemptsFile is the file in the day folder with all submitted answers.
+const AttemptsFile = "submissions.json"
+
+// Attempt is a submitted answer, and what the site said about it.
+type Attempt struct {
+	Time    time.Time    `json:"time"`
+	Part    int          `json:"part"`
+	Answer  string       `json:"answer"`
+	Verdict site.Verdict `json:"verdict"`
+	RetryAt *time.Time   `json:"retry_at,omitempty"`
+	Text    string       `json:"text,omitempty"` // of unknown replies
+}
+
+type Attempts []Attempt
+
+// LoadAttempts reads attempts of the day. A day without the file has none.
+func LoadAttempts(root string, day int) (Attempts, error) {
+	var attempts Attempts
+	bs, err := os.ReadFile(filepath.Join(root, aoc.Dir(day), AttemptsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return attempts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &attempts); err != nil {
+		return nil, fmt.Errorf("%s: %w", AttemptsFile, err)
+	}
+	return attempts, nil
+}
+
+// Save writes attempts of the day.
+func (as Attempts) Save(root string, day int) error {
+	bs, err := json.MarshalIndent(as, "", "  ")
+	if err != nil {
+		return err
+	}
+	bs = append(bs, '\n')
+	return os.WriteFile(filepath.Join(root, aoc.Dir(day), AttemptsFile), bs, 0644)
+}
+
+// Check returns why the answer shouldn't be submitted: the part is solved, the answer is known to be wrong,
+// it's out of bounds of too high and too low answers, or the site asked to wait.
+// Only a correct answer makes the part solved. "Already solved" is what the site says for any other level
+// than the one the day is at, like part 2 before part 1 is done, so it doesn't block anything.
+func (as Attempts) Check(part int, answer string, now time.Time) error {
+	var low, high string // closest known bounds
+	for _, a := range as {
+		if a.RetryAt != nil && now.Before(*a.RetryAt) {
+			return fmt.Errorf("too soon, the site asked to wait until %s, %v more", a.RetryAt.Local().Format(time.TimeOnly), a.RetryAt.Sub(now).Round(time.Second))
+		}
+		if a.Part != part {
+			continue
+		}
+		switch {
+		case a.Verdict == site.Correct:
+			return fmt.Errorf("part %d is already solved", part)
+		case a.Answer == answer && a.Verdict.IsWrong():
+			return fmt.Errorf("%s is already known to be %s", answer, a.Verdict)
+		}
+		switch {
+		case a.Verdict == site.TooHigh && (high == "" || less(a.Answer, high)):
+			high = a.Answer
+		case a.Verdict == site.TooLow && (low == "" || less(low, a.Answer)):
+			low = a.Answer
+		}
+	}
+	if high != "" && !less(answer, high) {
+		return fmt.Errorf("%s is too high, as %s is already too high", answer, high)
+	}
+	if low != "" && !less(low, answer) {
+		return fmt.Errorf("%s is too low, as %s is already too low", answer, low)
+	}
+	return nil
+}
+
+// less compares answers as ints. Answers which are not ints are never out of bounds.
+func less(a, b string) bool {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	return errA != nil || errB != nil || x < y
+}
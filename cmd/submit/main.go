@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	_ "github.com/metalim/adventofcode.2024.go/aoc/all"
+	"github.com/metalim/adventofcode.2024.go/aoc/site"
+)
+
+var (
+	Root     string
+	Day      int
+	Part     int
+	Input    string
+	Timeout  time.Duration
+	Force    bool
+	Interval time.Duration
+)
+
+// Submit the answer, or what the solver gives for input.txt, and remember what the site says about it
+func main() {
+	client := site.New()
+	flag.StringVar(&Root, "root", ".", "repository root with day folders")
+	flag.IntVar(&Day, "day", 0, "day of the answer")
+	flag.IntVar(&Part, "part", 0, "part of the answer, 1 or 2")
+	flag.StringVar(&Input, "input", "input.txt", "input file for the solver, if answer is not given")
+	flag.DurationVar(&Timeout, "timeout", time.Minute, "time limit for the solver")
+	flag.BoolVar(&Force, "f", false, "submit even if the answer is known to be wrong, or it's too soon")
+	flag.DurationVar(&Interval, "interval", client.Interval, "min time between requests to the site")
+	flag.Parse()
+	if Day < 1 || Day > 25 || Part < 1 || Part > 2 || flag.NArg() > 1 {
+		fmt.Println("Usage: go run ./cmd/submit -day N -part P [answer]")
+		os.Exit(1)
+	}
+	client.Interval = Interval
+
+	answer := flag.Arg(0)
+	if answer == "" {
+		var err error
+		answer, err = solve(Root, Day, Part, Input, Timeout)
+		catch(err)
+		fmt.Printf("Solver gives %s for %s\n", answer, Input)
+	}
+	catch(submit(os.Stdout, client, Root, Day, Part, answer, Force))
+}
+
+func solve(root string, day, part int, input string, timeout time.Duration) (string, error) {
+	puzzle, ok := aoc.Get(day)
+	if !ok {
+		return "", fmt.Errorf("day %d is not registered, give the answer", day)
+	}
+	aoc.Log = io.Discard
+	res := puzzle.RunFile(context.Background(), root, input, timeout, part)
+	if len(res) == 0 {
+		return "", fmt.Errorf("day %d has no part %d", day, part)
+	}
+	if res[0].Error != "" {
+		return "", fmt.Errorf("day %d part %d: %s", day, part, res[0].Error)
+	}
+	return res[0].Answer, nil
+}
+
+var now = time.Now
+
+func submit(w io.Writer, client *site.Client, root string, day, part int, answer string, force bool) error {
+	attempts, err := LoadAttempts(root, day)
+	if err != nil {
+		return err
+	}
+	if !force {
+		if err := attempts.Check(part, answer, now()); err != nil {
+			return err
+		}
+	}
+	reply, err := client.Submit(day, part, answer)
+	if err != nil {
+		return err
+	}
+	attempt := Attempt{Time: now().UTC().Truncate(time.Second), Part: part, Answer: answer, Verdict: reply.Verdict}
+	if reply.Wait > 0 {
+		retry := attempt.Time.Add(reply.Wait)
+		attempt.RetryAt = &retry
+	}
+	if reply.Verdict == site.Unknown {
+		attempt.Text = reply.Text
+	}
+	attempts = append(attempts, attempt)
+	if err := attempts.Save(root, day); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Day %d part %d: %s is %s", day, part, answer, reply.Verdict)
+	if reply.Wait > 0 {
+		fmt.Fprintf(w, ", wait %v before the next one", reply.Wait)
+	}
+	fmt.Fprintln(w)
+	switch reply.Verdict {
+	case site.Correct:
+		answers, err := aoc.LoadAnswers(root, day)
+		if err != nil {
+			return err
+		}
+		answers.Set("input.txt", part, answer)
+		return answers.Save(root, day)
+	case site.Unknown:
+		return fmt.Errorf("unknown reply:\n%s", reply.Text)
+	}
+	return nil
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
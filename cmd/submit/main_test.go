@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	"github.com/metalim/adventofcode.2024.go/aoc/site"
+)
+
+func article(text string) string {
+	return fmt.Sprintf("<!DOCTYPE html>\n<html><body><main>\n<article><p>%s</p></article>\n</main></body></html>", text)
+}
+
+const (
+	replyCorrect = `That's the right answer!  You are <span class="day-success">one gold star</span> closer to finding the Chief Historian. <a href="/2024/day/5#part2">[Continue to Part Two]</a>`
+	replyHigh    = `That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2024/about">about page</a>.  Please wait one minute before trying again. <a href="/2024/day/5">[Return to Day 5]</a>`
+	replyLow     = `That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again. <a href="/2024/day/5">[Return to Day 5]</a>`
+	replyWrong   = `That's not the right answer.  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again. <a href="/2024/day/5">[Return to Day 5]</a>`
+	replySoon    = `You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 23s left to wait. <a href="/2024/day/5">[Return to Day 5]</a>`
+	replySolved  = `You don't seem to be solving the right level.  Did you already complete it? <a href="/2024/day/5">[Return to Day 5]</a>`
+)
+
+// fakeSite checks answers of day 5 against 143, with replies of the site.
+type fakeSite struct {
+	*httptest.Server
+	submitted []string
+	reply     string // instead of checking
+}
+
+func newFakeSite(t *testing.T) *fakeSite {
+	s := &fakeSite{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/5/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		answer := r.FormValue("answer")
+		s.submitted = append(s.submitted, r.FormValue("level")+":"+answer)
+		if s.reply != "" {
+			io.WriteString(w, article(s.reply))
+			return
+		}
+		n, err := strconv.Atoi(answer)
+		switch {
+		case err != nil:
+			io.WriteString(w, article(replyWrong))
+		case n > 143:
+			io.WriteString(w, article(replyHigh))
+		case n < 143:
+			io.WriteString(w, article(replyLow))
+		default:
+			io.WriteString(w, article(replyCorrect))
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeSite) client() *site.Client {
+	return &site.Client{URL: s.URL, Session: "secret"}
+}
+
+func newRoot(t *testing.T) string {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "05"), 0755))
+	return root
+}
+
+// setClock makes submit think it's the time of clock.
+func setClock(t *testing.T, clock *time.Time) {
+	now = func() time.Time { return *clock }
+	t.Cleanup(func() { now = time.Now })
+}
+
+func TestSubmit(t *testing.T) {
+	s := newFakeSite(t)
+	root := newRoot(t)
+	clock := time.Date(2024, 12, 5, 5, 0, 0, 0, time.UTC)
+	setClock(t, &clock)
+	part := 1
+	submit := func(answer string) error {
+		return submit(io.Discard, s.client(), root, 5, part, answer, false)
+	}
+
+	require.NoError(t, submit("200"))
+	assert.ErrorContains(t, submit("100"), "too soon, the site asked to wait until", "one minute for wrong answers")
+	clock = clock.Add(time.Minute)
+	require.NoError(t, submit("100"))
+	assert.Equal(t, []string{"1:200", "1:100"}, s.submitted)
+
+	attempts, err := LoadAttempts(root, 5)
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, site.TooHigh, attempts[0].Verdict)
+	assert.Equal(t, site.TooLow, attempts[1].Verdict)
+	require.NotNil(t, attempts[1].RetryAt)
+	assert.Equal(t, 5*time.Minute, attempts[1].RetryAt.Sub(attempts[1].Time))
+	clock = clock.Add(5 * time.Minute)
+
+	assert.EqualError(t, submit("200"), "200 is already known to be too high")
+	assert.EqualError(t, submit("300"), "300 is too high, as 200 is already too high")
+	assert.EqualError(t, submit("100"), "100 is already known to be too low")
+	assert.EqualError(t, submit("50"), "50 is too low, as 100 is already too low")
+	assert.Len(t, s.submitted, 2, "refused answers are not submitted")
+
+	require.NoError(t, submit("143"))
+	answers, err := aoc.LoadAnswers(root, 5)
+	require.NoError(t, err)
+	answer, _ := answers.Get("input.txt", 1)
+	assert.Equal(t, "143", answer)
+	assert.EqualError(t, submit("150"), "part 1 is already solved")
+
+	// part 2 is not bound by part 1
+	part = 2
+	s.reply = replyWrong
+	require.NoError(t, submit("300"))
+	assert.Equal(t, "2:300", s.submitted[len(s.submitted)-1])
+}
+
+func TestSubmitWait(t *testing.T) {
+	s := newFakeSite(t)
+	root := newRoot(t)
+	s.reply = replySoon
+	require.NoError(t, submit(io.Discard, s.client(), root, 5, 1, "42", false))
+	err := submit(io.Discard, s.client(), root, 5, 1, "43", false)
+	assert.ErrorContains(t, err, "too soon, the site asked to wait until")
+	assert.Equal(t, []string{"1:42"}, s.submitted)
+
+	attempts, err := LoadAttempts(root, 5)
+	require.NoError(t, err)
+	assert.Equal(t, site.TooSoon, attempts[0].Verdict)
+	assert.Equal(t, 83*time.Second, attempts[0].RetryAt.Sub(attempts[0].Time))
+
+	// -f submits anyway
+	s.reply = replySolved
+	require.NoError(t, submit(io.Discard, s.client(), root, 5, 1, "43", true))
+	assert.Equal(t, []string{"1:42", "1:43"}, s.submitted)
+}
+
+func TestSubmitWrongLevel(t *testing.T) {
+	s := newFakeSite(t)
+	root := newRoot(t)
+	clock := time.Date(2024, 12, 5, 5, 0, 0, 0, time.UTC)
+	setClock(t, &clock)
+
+	// part 2 before part 1 is solved
+	s.reply = replySolved
+	require.NoError(t, submit(io.Discard, s.client(), root, 5, 2, "42", false))
+	attempts, err := LoadAttempts(root, 5)
+	require.NoError(t, err)
+	assert.Equal(t, site.Solved, attempts[0].Verdict)
+
+	s.reply = ""
+	require.NoError(t, submit(io.Discard, s.client(), root, 5, 1, "143", false))
+	s.reply = replyCorrect
+	require.NoError(t, submit(io.Discard, s.client(), root, 5, 2, "42", false), "not blocked by the wrong level")
+	assert.Equal(t, []string{"2:42", "1:143", "2:42"}, s.submitted)
+	assert.EqualError(t, submit(io.Discard, s.client(), root, 5, 2, "43", false), "part 2 is already solved")
+}
+
+func TestSubmitUnknown(t *testing.T) {
+	s := newFakeSite(t)
+	root := newRoot(t)
+	s.reply = "Something new."
+	err := submit(io.Discard, s.client(), root, 5, 1, "42", false)
+	assert.EqualError(t, err, "unknown reply:\nSomething new.")
+	attempts, err := LoadAttempts(root, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "Something new.", attempts[0].Text)
+}
+
+func TestSolve(t *testing.T) {
+	answers, err := aoc.LoadAnswers("../..", 5)
+	require.NoError(t, err)
+	want, ok := answers.Get("sample.txt", 2)
+	require.True(t, ok)
+	got, err := solve("../..", 5, 2, "sample.txt", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
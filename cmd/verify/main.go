@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/metalim/adventofcode.2024.go/aoc"
+	_ "github.com/metalim/adventofcode.2024.go/aoc/all"
+)
+
+type Status string
+
+const (
+	Pass    Status = "pass"
+	Fail    Status = "FAIL"
+	Missing Status = "missing"
+)
+
+type Check struct {
+	aoc.Result
+	Expected string
+	Status   Status
+}
+
+var (
+	Root     string
+	Days     string
+	Record   bool
+	Parallel int
+	Verbose  bool
+	Timeout  time.Duration
+)
+
+// Verify all days against recorded answers in NN/answers.json
+func main() {
+	flag.StringVar(&Root, "root", ".", "repository root with day folders")
+	flag.StringVar(&Days, "day", "all", "days to verify, like 5, 5-12 or 1,3,20-")
+	flag.BoolVar(&Record, "record", false, "run inputs without answers, and record what they give")
+	flag.IntVar(&Parallel, "parallel", 1, "number of days to verify at once")
+	flag.BoolVar(&Verbose, "v", false, "print passed and missing checks too")
+	flag.DurationVar(&Timeout, "timeout", 0, "time limit per part, 0 for none")
+	flag.Parse()
+
+	days, err := aoc.ParseDays(Days)
+	catch(err)
+	aoc.Log = io.Discard
+
+	perDay := make([][]Check, len(days))
+	sem := make(chan struct{}, max(Parallel, 1))
+	var wg sync.WaitGroup
+	for i, day := range days {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			perDay[i] = verifyDay(day)
+		}()
+	}
+	wg.Wait()
+
+	counts := map[Status]int{}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tInput\tPart\tStatus\tExpected\tGot\tTime")
+	for _, checks := range perDay {
+		for _, c := range checks {
+			counts[c.Status]++
+			if c.Status != Fail && !Verbose {
+				continue
+			}
+			got := c.Answer
+			if c.Error != "" {
+				got = "error: " + c.Error
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\t%v\n", c.Day, c.Input, c.Part, c.Status, c.Expected, got, c.Time)
+		}
+	}
+	tw.Flush()
+	fmt.Printf("%d passed, %d failed, %d missing\n", counts[Pass], counts[Fail], counts[Missing])
+	if counts[Fail] > 0 {
+		os.Exit(1)
+	}
+}
+
+func verifyDay(day int) []Check {
+	puzzle, _ := aoc.Get(day)
+	answers, err := aoc.LoadAnswers(Root, day)
+	catch(err)
+	inputs, err := aoc.Inputs(Root, day)
+	catch(err)
+
+	var checks []Check
+	var recorded bool
+	for _, input := range inputs {
+		var parts []int
+		for _, part := range puzzle.Parts() {
+			if _, ok := answers.Get(input, part); ok || Record {
+				parts = append(parts, part)
+				continue
+			}
+			checks = append(checks, Check{Result: aoc.Result{Day: day, Part: part, Input: input}, Status: Missing})
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		for _, res := range puzzle.RunFile(context.Background(), Root, input, Timeout, parts...) {
+			expected, ok := answers.Get(input, res.Part)
+			c := Check{Result: res, Expected: expected}
+			switch {
+			case !ok && res.Error == "":
+				answers.Set(input, res.Part, res.Answer)
+				recorded = true
+				c.Status = Missing
+			case !ok:
+				c.Status = Missing
+			case res.Error == "" && res.Answer == expected:
+				c.Status = Pass
+			default:
+				c.Status = Fail
+			}
+			checks = append(checks, c)
+		}
+	}
+	if recorded {
+		catch(answers.Save(Root, day))
+	}
+	return checks
+}
+
+func catch(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
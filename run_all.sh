@@ -1,18 +1,5 @@
 #!/usr/bin/env bash
 
-echo "Building all..."
-for folder in [0-2][0-9]/; do
-  if [ -d "$folder" ]; then
-    echo $folder
-    go build -o bin/ ./$folder
-  fi
-done
-
-echo "Running all..."
-time for bin in bin/*; do
-  if [ -f "$bin" ]; then
-    echo
-    echo $(basename $bin)
-    $bin "$(basename $bin)/${1:-input.txt}"
-  fi
-done
+# Runs all days on the given input file name (input.txt by default).
+# Use `go run ./cmd/aoc run -h` for more options.
+go run ./cmd/aoc run -input "${1:-input.txt}"